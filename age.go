@@ -0,0 +1,59 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const (
+	// ageFieldName is the name of the number field holding the days since the
+	// item was created.
+	ageFieldName = "Age (days)"
+	// daysInStatusFieldName is the name of the number field holding the days
+	// since the item status was last set.
+	daysInStatusFieldName = "Days in status"
+)
+
+// computedFields are the fields derived from the current time, which change on
+// every run, so that their updates do not count as activity on the item.
+var computedFields = map[string]bool{
+	ageFieldName:              true,
+	daysInStatusFieldName:     true,
+	daysSinceCommentFieldName: true,
+}
+
+// syncAge sets the age fields, which views cannot compute but can sort and
+// color by.
+func (s *syncer) syncAge(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	now := time.Now()
+	if s.syncsField(ageFieldName) {
+		days := int(now.Sub(issue.GetCreatedAt()).Hours() / 24)
+		if err := s.client.setNumberField(ctx, s.project, item, ageFieldName, float64(days)); err != nil {
+			return err
+		}
+	}
+
+	if !s.syncsField(daysInStatusFieldName) || item.StatusChangedAt.IsZero() {
+		return nil
+	}
+	days := int(now.Sub(item.StatusChangedAt).Hours() / 24)
+	return s.client.setNumberField(ctx, s.project, item, daysInStatusFieldName, float64(days))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// runAgenda prints the agenda of the triage meeting: the items to re-triage
+// and the items waiting for triage, oldest first.
+func runAgenda(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("agenda", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+	fmt.Print(renderAgenda(prof, newSnapshot(project, items)))
+	return nil
+}
+
+func renderAgenda(prof profile, snap *boardSnapshot) string {
+	var retriage []snapshotItem
+	for _, item := range snap.Items {
+		if item.State == "OPEN" && item.Status == statusRetriage {
+			retriage = append(retriage, item)
+		}
+	}
+	untriaged := untriagedItems(prof, snap)
+	for _, items := range [][]snapshotItem{retriage, untriaged} {
+		sort.Slice(items, func(i, j int) bool {
+			return createdBefore(items[i], items[j])
+		})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# SIG Auth triage meeting agenda for %s\n", snap.Time.Format(dateFormat))
+	printAgendaSection(&b, "Accepted items to re-triage", "They saw no progress for a long time: keep them accepted, move them to the backlog or close them.", retriage)
+	printAgendaSection(&b, "Items waiting for triage", "", untriaged)
+	return b.String()
+}
+
+func printAgendaSection(b *strings.Builder, title, description string, items []snapshotItem) {
+	fmt.Fprintf(b, "\n## %s (%d)\n\n", title, len(items))
+	if len(items) == 0 {
+		b.WriteString("None.\n")
+		return
+	}
+	if description != "" {
+		fmt.Fprintf(b, "%s\n\n", description)
+	}
+	for _, item := range items {
+		fmt.Fprintf(b, "- [ ] %s %s\n", item.URL, item.Title)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// failureAlert is the payload posted to the failure webhook.
+type failureAlert struct {
+	Profile string `json:"profile"`
+	// Phase is the step of the run that failed.
+	Phase string `json:"phase"`
+	Error string `json:"error"`
+	// LogsURL links to the logs of the run, empty when unknown.
+	LogsURL string `json:"logsURL,omitempty"`
+}
+
+// postFailureAlert posts the failure of a sync run to the webhook. With the
+// slack format, the payload is a Slack incoming webhook message, otherwise it is
+// the failureAlert as JSON.
+func postFailureAlert(webhookURL, format, profileName string, stats *runStats, runErr error) error {
+	// The run context may have expired, which is one of the failures worth reporting.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	alert := failureAlert{
+		Profile: profileName,
+		Phase:   stats.phase,
+		Error:   runErr.Error(),
+		LogsURL: runLogsURL(),
+	}
+	var payload interface{} = alert
+	if format == "slack" {
+		text := fmt.Sprintf("sig-auth-tools sync of profile %q failed while %s: %s", alert.Profile, alert.Phase, alert.Error)
+		if alert.LogsURL != "" {
+			text += fmt.Sprintf(" (<%s|logs>)", alert.LogsURL)
+		}
+		payload = map[string]string{"text": text}
+	}
+	return postWebhook(ctx, webhookURL, payload)
+}
+
+// postWebhook posts the payload as JSON to the webhook. The returned errors do
+// not include the webhook URL.
+func postWebhook(ctx context.Context, webhookURL string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", redactURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting to webhook failed: %w", redactURL(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		// The URL is not included as Slack webhook URLs are secrets.
+		return fmt.Errorf("posting to webhook failed with status %s", resp.Status)
+	}
+	return nil
+}
+
+// redactURL returns the error without the URL wrapped around it by net/http and
+// net/url, as Slack webhook URLs are secrets.
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// runLogsURL returns the URL of the GitHub Actions run the tool is running in,
+// or an empty string.
+func runLogsURL() string {
+	server, repo, runID := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")
+	if server == "" || repo == "" || runID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, runID)
+}
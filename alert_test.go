@@ -0,0 +1,54 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostWebhookRedactsURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer srv.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	const secret = "T00000000/B00000000/XXXXXXXXXXXXXXXXXXXXXXXX"
+	for _, tc := range []struct {
+		name string
+		url  string
+	}{
+		{"error status", srv.URL + "/services/" + secret},
+		{"connection refused", closed.URL + "/services/" + secret},
+		{"invalid URL", "https://hooks.slack.com/services/" + secret + "/%zz"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := postWebhook(context.Background(), tc.url, map[string]string{"text": "sync failed"})
+			if err == nil {
+				t.Fatal("postWebhook() succeeded, want an error")
+			}
+			if strings.Contains(err.Error(), secret) {
+				t.Errorf("postWebhook() = %q, which includes the webhook URL", err)
+			}
+		})
+	}
+}
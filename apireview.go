@@ -0,0 +1,146 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const (
+	// statusAPIReview is the status of PRs in the API review queue that change
+	// auth API types.
+	statusAPIReview = "API Review"
+	// defaultAPIReviewRepo is the repository of the API review queue.
+	defaultAPIReviewRepo = "kubernetes/kubernetes"
+	// defaultAPIReviewLabel is the label of PRs in the API review queue.
+	defaultAPIReviewLabel = "api-review"
+)
+
+// defaultAuthAPIPaths are the path prefixes of kubernetes/kubernetes holding the
+// authentication, authorization and certificates API types.
+var defaultAuthAPIPaths = []string{
+	"pkg/apis/authentication/",
+	"pkg/apis/authorization/",
+	"pkg/apis/certificates/",
+	"pkg/apis/rbac/",
+	"staging/src/k8s.io/api/authentication/",
+	"staging/src/k8s.io/api/authorization/",
+	"staging/src/k8s.io/api/certificates/",
+	"staging/src/k8s.io/api/rbac/",
+	"staging/src/k8s.io/apiserver/pkg/apis/apiserver/",
+}
+
+// apiReview configures the discovery of PRs in the API review queue that change
+// auth API types, whatever their SIG labels.
+type apiReview struct {
+	// Repo is the repository of the queue, in owner/name form. Empty means
+	// defaultAPIReviewRepo.
+	Repo string `json:"repo,omitempty"`
+	// Label is the label of PRs in the queue. Empty means defaultAPIReviewLabel.
+	Label string `json:"label,omitempty"`
+	// Paths are the path prefixes of the auth API types. Empty means
+	// defaultAuthAPIPaths.
+	Paths []string `json:"paths,omitempty"`
+}
+
+// source returns the source importing the API review queue. The status is not
+// an initial status of the profile, so the other sources leave the PRs in it
+// once moved.
+func (r *apiReview) source() source {
+	repo, label := r.Repo, r.Label
+	if repo == "" {
+		repo = defaultAPIReviewRepo
+	}
+	if label == "" {
+		label = defaultAPIReviewLabel
+	}
+	return source{Repos: []string{repo}, Labels: []string{label}, IssueStatus: statusAPIReview, PullRequestStatus: statusAPIReview}
+}
+
+// syncAPIReview imports the open PRs of the API review queue that change auth
+// API types into the API Review status, so that SIG Auth API reviewers do not
+// miss them. PRs already triaged elsewhere on the board are left alone.
+func (s *syncer) syncAPIReview(ctx context.Context) error {
+	review := s.profile.APIReview
+	if review == nil {
+		return nil
+	}
+	src := review.source()
+	repoName, paths := src.Repos[0], review.Paths
+	if len(paths) == 0 {
+		paths = defaultAuthAPIPaths
+	}
+	owner, repo, err := splitRepo(repoName)
+	if err != nil {
+		return err
+	}
+	if !s.filter.includesRepo(owner, repo) {
+		return nil
+	}
+
+	fmt.Printf("Looking for %s PRs changing auth APIs in %s\n", src.Labels[0], repoName)
+	items, err := s.client.listIssues(ctx, owner, repo, &github.IssueListByRepoOptions{Labels: src.Labels})
+	if err != nil {
+		return err
+	}
+	for _, item := range s.filter.forSource(src).filterItems(items) {
+		if !item.IsPullRequest() {
+			continue
+		}
+		changes, err := s.client.changesPaths(ctx, owner, repo, item.GetNumber(), paths)
+		if err != nil {
+			return err
+		}
+		if !changes {
+			continue
+		}
+		s.stats.synced++
+		fmt.Printf("adding API review [%d] %s to project\n", *item.Number, *item.Title)
+		if err := s.syncItem(ctx, src, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changesPaths reports whether the PR changes a file, other than a test, under
+// one of the path prefixes.
+func (c *ghClient) changesPaths(ctx context.Context, owner, repo string, number int, prefixes []string) (bool, error) {
+	opts := &github.ListOptions{PerPage: perPage}
+	for {
+		files, resp, err := c.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return false, err
+		}
+		for _, file := range files {
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(file.GetFilename(), prefix) && !strings.HasSuffix(file.GetFilename(), "_test.go") {
+					return true, nil
+				}
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return false, nil
+}
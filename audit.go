@@ -0,0 +1,97 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Values of auditEntry.Action.
+const (
+	auditAdd     = "add"
+	auditUpdate  = "update"
+	auditClear   = "clear"
+	auditDelete  = "delete"
+	auditArchive = "archive"
+)
+
+// auditEntry records a single board mutation.
+type auditEntry struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Project string    `json:"project"`
+	ItemID  string    `json:"itemId"`
+	// Content is the URL of the issue or PR, if known.
+	Content string `json:"content,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after,omitempty"`
+}
+
+// auditLog appends board mutations to a file as JSON lines, so that changes
+// made by the tool can be explained long after the run.
+type auditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func openAuditLog(path string) (*auditLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLog{file: f}, nil
+}
+
+// record appends the entry to the log. It is a no-op on a nil log.
+func (l *auditLog) record(entry auditEntry) {
+	if l == nil {
+		return
+	}
+	entry.Time = time.Now().UTC()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode audit entry: %v\n", err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write audit entry: %v\n", err)
+	}
+}
+
+func (l *auditLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// recordMutation counts a board mutation and records it in the audit log and
+// the changelog.
+func (c *ghClient) recordMutation(entry auditEntry) {
+	c.mutations++
+	c.audit.record(entry)
+	c.changelog.record(entry, nil)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// runBackfill records the items of the sources closed since --since, and never
+// added to the board, into backfill snapshots taken at their close time, so
+// that report analytics has a baseline from before the board existed.
+func runBackfill(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	common.register(fs)
+	var since dateFlag
+	fs.Var(&since, "since", "record items closed on or after this date, e.g. 2023-01-01")
+	snapshotDir := fs.String("snapshot-dir", "", "directory holding the board snapshots written by sync")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if since.IsZero() {
+		return fmt.Errorf("--since is required")
+	}
+	if *snapshotDir == "" {
+		return fmt.Errorf("--snapshot-dir is required")
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	filter := itemFilter{
+		pullRequestsOnly: prof.PullRequestsOnly,
+		excludedAuthors:  stringSet(cfg.BotAuthors),
+		exemptLabels:     cfg.ExemptLabels,
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+	// Items on the board are already recorded by the sync snapshots.
+	onBoard := itemsByContentID(items)
+
+	// Items closed in the same second share a snapshot.
+	snaps := map[time.Time]*boardSnapshot{}
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			owner := repo.GetOwner().GetLogin()
+			closed, err := client.listIssues(ctx, owner, *repo.Name, &github.IssueListByRepoOptions{
+				State:  "closed",
+				Labels: src.Labels,
+				Since:  since.Time,
+			})
+			if err != nil {
+				return err
+			}
+			for _, issue := range filter.forSource(src).filterItems(closed) {
+				// Since filters on the update time, which may be later than the close time.
+				if issue.GetClosedAt().Before(since.Time) || onBoard[issue.GetNodeID()] != nil {
+					continue
+				}
+				item, err := client.backfillItem(ctx, owner, *repo.Name, issue)
+				if err != nil {
+					return err
+				}
+				closedAt := issue.GetClosedAt().UTC().Truncate(time.Second)
+				snap, ok := snaps[closedAt]
+				if !ok {
+					snap = &boardSnapshot{Time: closedAt, Project: prof.Project, Backfill: true}
+					snaps[closedAt] = snap
+				}
+				snap.Items = append(snap.Items, item)
+			}
+		}
+	}
+
+	for _, snap := range snaps {
+		if _, err := saveSnapshot(*snapshotDir, snap); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("recorded %d items closed since %s into %d backfill snapshots in %s\n", countItems(snaps), since.Format(dateFormat), len(snaps), *snapshotDir)
+	return nil
+}
+
+// backfillItem returns the snapshot item of a closed issue or PR. It is keyed
+// by the node ID of its content since it has no board item.
+func (c *ghClient) backfillItem(ctx context.Context, owner, repo string, issue *github.Issue) (snapshotItem, error) {
+	item := snapshotItem{
+		ID:         issue.GetNodeID(),
+		Type:       string(githubql.ProjectV2ItemTypeIssue),
+		ContentID:  issue.GetNodeID(),
+		Repository: owner + "/" + repo,
+		Number:     issue.GetNumber(),
+		Title:      issue.GetTitle(),
+		URL:        issue.GetHTMLURL(),
+		State:      "CLOSED",
+		CreatedAt:  timeOrNil(issue.GetCreatedAt()),
+	}
+	for _, label := range issue.Labels {
+		item.Labels = append(item.Labels, label.GetName())
+	}
+	if issue.IsPullRequest() {
+		item.Type = string(githubql.ProjectV2ItemTypePullRequest)
+		// The issues API does not tell merged and closed PRs apart.
+		pr, _, err := c.PullRequests.Get(ctx, owner, repo, issue.GetNumber())
+		if err != nil {
+			return snapshotItem{}, err
+		}
+		if pr.GetMerged() {
+			item.State = "MERGED"
+		}
+	}
+	return item, nil
+}
+
+func countItems(snaps map[time.Time]*boardSnapshot) int {
+	n := 0
+	for _, snap := range snaps {
+		n += len(snap.Items)
+	}
+	return n
+}
@@ -0,0 +1,192 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+const (
+	// graphqlURL is the endpoint batched mutations are posted to, bypassing
+	// v4Client which sends a single mutation per request.
+	graphqlURL = "https://api.github.com/graphql"
+	// batchPause is the pause between batches, as GitHub asks integrations to
+	// wait at least a second between mutations to avoid secondary rate limits.
+	batchPause = time.Second
+)
+
+// pendingUpdate is a queued field update and the audit entry it is recorded
+// with once flushed. input is an UpdateProjectV2ItemFieldValueInput, or a
+// ClearProjectV2ItemFieldValueInput for a cleared field.
+type pendingUpdate struct {
+	input interface{}
+	entry auditEntry
+}
+
+// updateQueue buffers field updates so that they are sent in batches, one
+// GraphQL request per batch.
+type updateQueue struct {
+	size    int
+	pending []pendingUpdate
+	// queued and flushed count the updates over the whole run, for progress,
+	// and failed those that were rejected.
+	queued  int
+	flushed int
+	failed  int
+}
+
+// queueUpdate queues the field update, flushing the queue when a batch is full.
+func (c *ghClient) queueUpdate(ctx context.Context, update pendingUpdate) error {
+	c.queue.pending = append(c.queue.pending, update)
+	c.queue.queued++
+	if len(c.queue.pending) < c.queue.size {
+		return nil
+	}
+	return c.flushUpdates(ctx)
+}
+
+// flushUpdates sends the queued field updates in batches. It is a no-op when
+// updates are not queued.
+func (c *ghClient) flushUpdates(ctx context.Context) error {
+	if c.queue == nil {
+		return nil
+	}
+	for len(c.queue.pending) > 0 {
+		n := c.queue.size
+		if n > len(c.queue.pending) {
+			n = len(c.queue.pending)
+		}
+		if c.queue.flushed > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(batchPause):
+			}
+		}
+		batch := c.queue.pending[:n]
+		c.queue.pending = c.queue.pending[n:]
+
+		if err := c.sendUpdates(ctx, batch); err != nil {
+			return err
+		}
+		c.queue.flushed += n
+		fmt.Printf("flushed %d/%d field updates, %d failed\n", c.queue.flushed, c.queue.queued, c.queue.failed)
+	}
+	return nil
+}
+
+// sendUpdates sends the updates as aliased mutations of a single request. Each
+// update succeeds or fails on its own: failed updates are recorded and counted
+// in the queue, and only the failure of the request as a whole is returned.
+func (c *ghClient) sendUpdates(ctx context.Context, batch []pendingUpdate) error {
+	var params, fields []string
+	variables := make(map[string]interface{}, len(batch))
+	for i, update := range batch {
+		inputType, mutation := "UpdateProjectV2ItemFieldValueInput", "updateProjectV2ItemFieldValue"
+		if _, ok := update.input.(githubql.ClearProjectV2ItemFieldValueInput); ok {
+			inputType, mutation = "ClearProjectV2ItemFieldValueInput", "clearProjectV2ItemFieldValue"
+		}
+		params = append(params, fmt.Sprintf("$i%d: %s!", i, inputType))
+		fields = append(fields, fmt.Sprintf("u%d: %s(input: $i%d) { clientMutationId }", i, mutation, i))
+		variables[fmt.Sprintf("i%d", i)] = update.input
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     fmt.Sprintf("mutation(%s) { %s }", strings.Join(params, ", "), strings.Join(fields, " ")),
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphqlURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.recordBatch(batch, nil, err)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("batched update failed with status %s", resp.Status)
+		c.recordBatch(batch, nil, err)
+		return err
+	}
+
+	var result struct {
+		Errors []struct {
+			Message string        `json:"message"`
+			Path    []interface{} `json:"path"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		// The updates were sent, but whether they were applied is unknown.
+		err = fmt.Errorf("reading the batched update response: %w", err)
+		c.recordBatch(batch, nil, err)
+		return err
+	}
+
+	// Failures of single updates name their alias, errors without a path are
+	// errors of the request, e.g. an invalid query.
+	failed := make(map[string]error)
+	var msgs []string
+	for _, e := range result.Errors {
+		if len(e.Path) == 0 {
+			msgs = append(msgs, e.Message)
+			continue
+		}
+		failed[fmt.Sprint(e.Path[0])] = errors.New(e.Message)
+	}
+	if len(msgs) > 0 {
+		err := fmt.Errorf("batched update failed: %s", strings.Join(msgs, "; "))
+		c.recordBatch(batch, nil, err)
+		return err
+	}
+	c.recordBatch(batch, failed, nil)
+	return nil
+}
+
+// recordBatch records the outcome of each update of the batch. failed maps
+// aliases to their error, err is the error of the batch as a whole.
+func (c *ghClient) recordBatch(batch []pendingUpdate, failed map[string]error, err error) {
+	for i, update := range batch {
+		updateErr := err
+		if updateErr == nil {
+			updateErr = failed[fmt.Sprintf("u%d", i)]
+		}
+		if updateErr != nil {
+			if err == nil {
+				fmt.Printf("failed to update %q on %s: %v\n", update.entry.Field, update.entry.Content, updateErr)
+				c.queue.failed++
+			}
+			c.changelog.record(update.entry, updateErr)
+			continue
+		}
+		c.recordMutation(update.entry)
+	}
+}
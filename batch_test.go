@@ -0,0 +1,103 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// queuedClient returns a client queuing updates in batches of size, answering
+// the batched mutations with respond.
+func queuedClient(t *testing.T, size int, respond func(req graphqlRequest) string) *ghClient {
+	client := newTestClient(t, respond)
+	client.queue = &updateQueue{size: size}
+	client.changelog = &changelog{}
+	return client
+}
+
+// queueTextUpdates queues an update of the Notes field of n items.
+func queueTextUpdates(t *testing.T, client *ghClient, n int) {
+	t.Helper()
+	p := &project{ID: "project", Title: "SIG Auth", fields: map[string]*projectField{notesFieldName: {ID: "notes", Name: notesFieldName}}}
+	for i := 0; i < n; i++ {
+		item := &projectItem{ID: i, values: map[string]string{}}
+		if err := client.setTextField(context.Background(), p, item, notesFieldName, "needs a KEP"); err != nil {
+			t.Fatalf("queuing update %d: %v", i, err)
+		}
+	}
+}
+
+func outcomes(l *changelog) []string {
+	var outcomes []string
+	for _, e := range l.entries {
+		outcomes = append(outcomes, e.Outcome)
+	}
+	return outcomes
+}
+
+func TestSendUpdatesPartialFailure(t *testing.T) {
+	requests := 0
+	client := queuedClient(t, 3, func(req graphqlRequest) string {
+		requests++
+		if !strings.HasPrefix(req.Query, "mutation") {
+			t.Errorf("unexpected query %q, queued updates must not be re-read", req.Query)
+		}
+		return `{"data": {"u0": {"clientMutationId": null}, "u1": null, "u2": {"clientMutationId": null}}, "errors": [{"message": "item was archived", "path": ["u1"]}]}`
+	})
+
+	// The batch is flushed when the third update is queued, whose item is
+	// unrelated to the failure.
+	queueTextUpdates(t, client, 3)
+	if err := client.flushUpdates(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want a single batch", requests)
+	}
+	if got, want := strings.Join(outcomes(client.changelog), ","), "ok,failed,ok"; got != want {
+		t.Errorf("changelog outcomes = %s, want %s", got, want)
+	}
+	if client.queue.failed != 1 || client.mutations != 2 {
+		t.Errorf("failed = %d, mutations = %d, want 1 and 2", client.queue.failed, client.mutations)
+	}
+}
+
+func TestSendUpdatesRequestFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		response string
+	}{{
+		name:     "error without path",
+		response: `{"errors": [{"message": "Parse error on \"$i0\""}]}`,
+	}, {
+		name:     "unreadable response",
+		response: `{"data": `,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := queuedClient(t, 2, func(req graphqlRequest) string { return tc.response })
+			queueTextUpdates(t, client, 1)
+			if err := client.flushUpdates(context.Background()); err == nil {
+				t.Fatal("flushUpdates() succeeded, want the error of the request")
+			}
+			if got, want := strings.Join(outcomes(client.changelog), ","), "failed"; got != want {
+				t.Errorf("changelog outcomes = %s, want %s", got, want)
+			}
+		})
+	}
+}
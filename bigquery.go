@@ -0,0 +1,149 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2/google"
+)
+
+// bigQueryInsertScope is the OAuth scope needed to stream rows into BigQuery.
+const bigQueryInsertScope = "https://www.googleapis.com/auth/bigquery.insertdata"
+
+// bigQueryInsertBatch is the number of rows sent per insertAll request.
+const bigQueryInsertBatch = 500
+
+// bigQueryTable identifies a BigQuery table.
+type bigQueryTable struct {
+	Project string `json:"project"`
+	Dataset string `json:"dataset"`
+	Table   string `json:"table"`
+}
+
+// bigQueryRow is the record streamed for each board item on every run. Status
+// transitions and ages are derived by comparing rows across runs.
+type bigQueryRow struct {
+	RunTime    string   `json:"run_time"`
+	Project    string   `json:"project"`
+	ItemID     string   `json:"item_id"`
+	Type       string   `json:"type"`
+	Repository string   `json:"repository,omitempty"`
+	Number     int      `json:"number,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	State      string   `json:"state,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
+	CreatedAt  string   `json:"created_at,omitempty"`
+	AgeDays    int      `json:"age_days,omitempty"`
+}
+
+// exportToBigQuery streams a row per snapshot item into the table using the
+// tabledata.insertAll API and Application Default Credentials.
+func exportToBigQuery(ctx context.Context, table bigQueryTable, snap *boardSnapshot) error {
+	client, err := google.DefaultClient(ctx, bigQueryInsertScope)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://bigquery.googleapis.com/bigquery/v2/projects/%s/datasets/%s/tables/%s/insertAll",
+		table.Project, table.Dataset, table.Table)
+	runTime := snap.Time.Format(time.RFC3339)
+
+	type insertRow struct {
+		// InsertID lets BigQuery drop duplicates when a request is retried.
+		InsertID string      `json:"insertId"`
+		JSON     bigQueryRow `json:"json"`
+	}
+	var rows []insertRow
+	for _, item := range snap.Items {
+		row := bigQueryRow{
+			RunTime:    runTime,
+			Project:    snap.Project,
+			ItemID:     item.ID,
+			Type:       item.Type,
+			Repository: item.Repository,
+			Number:     item.Number,
+			URL:        item.URL,
+			State:      item.State,
+			Status:     item.Status,
+			Labels:     item.Labels,
+		}
+		if item.CreatedAt != nil {
+			row.CreatedAt = item.CreatedAt.Format(time.RFC3339)
+			row.AgeDays = int(snap.Time.Sub(*item.CreatedAt).Hours() / 24)
+		}
+		rows = append(rows, insertRow{InsertID: runTime + "/" + item.ID, JSON: row})
+	}
+
+	for start := 0; start < len(rows); start += bigQueryInsertBatch {
+		end := start + bigQueryInsertBatch
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if err := insertAll(ctx, client, url, rows[start:end]); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("exported %d rows to BigQuery table %s.%s.%s\n", len(rows), table.Project, table.Dataset, table.Table)
+	return nil
+}
+
+func insertAll(ctx context.Context, client *http.Client, url string, rows interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"rows": rows})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("BigQuery insertAll failed with status %s: %s", resp.Status, data)
+	}
+
+	var result struct {
+		InsertErrors []json.RawMessage `json:"insertErrors"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	if len(result.InsertErrors) > 0 {
+		return fmt.Errorf("BigQuery insertAll rejected %d rows, first error: %s", len(result.InsertErrors), result.InsertErrors[0])
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const (
+	// blockedFieldName is the name of the text field holding the open items
+	// blocking an item, or "Yes" for items only carrying blockedLabel.
+	blockedFieldName = "Blocked"
+	// blockedLabel marks items blocked on something that is not an issue.
+	blockedLabel = "blocked"
+)
+
+// blockedByRE matches the phrases referencing a blocking item, e.g. "blocked
+// by #123" or "depends on kubernetes/kubernetes#123".
+var blockedByRE = regexp.MustCompile(`(?i)\b(?:blocked\s+(?:by|on)|depends\s+on)\s*:?\s*((?:[\w.-]+/[\w.-]+)?#\d+|https://github\.com/[\w.-]+/[\w.-]+/(?:issues|pull)/\d+)`)
+
+// blockingReferences returns the items the issue body says it is blocked by, in
+// owner/repo#number form.
+func blockingReferences(issue *github.Issue) []string {
+	owner, repo := issueRepo(issue)
+	var refs []string
+	for _, m := range blockedByRE.FindAllStringSubmatch(issue.GetBody(), -1) {
+		if ref := taskReferenceRE.FindStringSubmatch(m[1]); ref != nil {
+			refs = append(refs, resolveReference(ref, owner, repo))
+		}
+	}
+	return refs
+}
+
+// syncBlocked sets the Blocked field to the blocking items that are still open,
+// and clears it once they are all closed and the item is not labeled blocked.
+func (s *syncer) syncBlocked(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	var open []string
+	seen := map[string]bool{}
+	for _, ref := range blockingReferences(issue) {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		nameWithOwner, number, _ := strings.Cut(ref, "#")
+		owner, repo, err := splitRepo(nameWithOwner)
+		if err != nil {
+			return err
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return err
+		}
+		blocking, resp, err := s.client.Issues.Get(ctx, owner, repo, n)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// Deleted, or in a repository we cannot read.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if blocking.GetState() == "open" {
+			open = append(open, ref)
+		}
+	}
+
+	value := strings.Join(open, ", ")
+	if value == "" && hasLabel(issue, blockedLabel) {
+		value = "Yes"
+	}
+	if value == "" {
+		return s.client.clearField(ctx, s.project, item, blockedFieldName)
+	}
+	return s.client.setTextField(ctx, s.project, item, blockedFieldName, value)
+}
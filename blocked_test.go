@@ -0,0 +1,52 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+)
+
+func TestBlockingReferences(t *testing.T) {
+	for _, tc := range []struct {
+		name, body string
+		want       []string
+	}{
+		{name: "no references", body: "Fixes #12."},
+		{name: "same repository", body: "Blocked by #123.", want: []string{"kubernetes/kubernetes#123"}},
+		{name: "other repository", body: "depends on kubernetes/enhancements#3299", want: []string{"kubernetes/enhancements#3299"}},
+		{name: "URL", body: "blocked on: https://github.com/kubernetes-sigs/secrets-store-csi-driver/pull/42", want: []string{"kubernetes-sigs/secrets-store-csi-driver#42"}},
+		{
+			name: "several references",
+			body: "Blocked by #1\nDepends on #2 and blocked on kubernetes/website#3",
+			want: []string{"kubernetes/kubernetes#1", "kubernetes/kubernetes#2", "kubernetes/website#3"},
+		},
+		{name: "mention", body: "See #123, which is blocked by a design decision.", want: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := &github.Issue{
+				RepositoryURL: github.String("https://api.github.com/repos/kubernetes/kubernetes"),
+				Body:          github.String(tc.body),
+			}
+			if got := blockingReferences(issue); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("blockingReferences() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBudgetDeferPercent is the share of the quota, in percent, below
+	// which deferrable commands are skipped, unless $API_BUDGET_DEFER_PERCENT
+	// is set.
+	defaultBudgetDeferPercent = 30
+	// budgetSaveInterval is the minimum time between writes of the budget file.
+	budgetSaveInterval = 5 * time.Second
+)
+
+// deferrableCommands are the scheduled, lower-priority commands skipped when
+// the shared quota runs low, leaving it to sync and the plugin.
+var deferrableCommands = map[string]bool{
+	"report":         true,
+	"weekly-report":  true,
+	"triage-party":   true,
+	"audit-teams":    true,
+	"audit-branches": true,
+	"audit-repos":    true,
+	"health":         true,
+	"vulncheck":      true,
+	"backfill":       true,
+	"validate":       true,
+}
+
+// errDeferred is returned by checkBudget when a command is skipped to save the
+// shared quota.
+var errDeferred = errors.New("deferred")
+
+// budgetQuota is the last known quota of a token for an API resource.
+type budgetQuota struct {
+	Remaining int       `json:"remaining"`
+	Limit     int       `json:"limit"`
+	Reset     time.Time `json:"reset"`
+	Observed  time.Time `json:"observed"`
+}
+
+// budgetState is the content of the budget file, the quotas by token ID and
+// resource, e.g. "1a2b3c4d/graphql".
+type budgetState struct {
+	Quotas map[string]budgetQuota `json:"quotas"`
+}
+
+// apiBudget is the budget file given by $API_BUDGET_FILE, shared by the
+// scheduled jobs using the same tokens so that each knows what the others
+// consumed in the current rate limit window.
+type apiBudget struct {
+	path string
+
+	mu    sync.Mutex
+	state budgetState
+	saved time.Time
+}
+
+// openAPIBudget returns the budget of $API_BUDGET_FILE, nil if it is not set.
+func openAPIBudget() *apiBudget {
+	path := os.Getenv("API_BUDGET_FILE")
+	if path == "" {
+		return nil
+	}
+	return &apiBudget{path: path, state: readBudgetState(path)}
+}
+
+// readBudgetState returns the state of the budget file, empty if it does not
+// exist or cannot be read.
+func readBudgetState(path string) budgetState {
+	state := budgetState{Quotas: map[string]budgetQuota{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		fmt.Printf("ignoring invalid budget file %q: %v\n", path, err)
+	}
+	if state.Quotas == nil {
+		state.Quotas = map[string]budgetQuota{}
+	}
+	return state
+}
+
+// tokenID identifies a token in the budget file without revealing it.
+func tokenID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:4])
+}
+
+// record updates the quota of the token, writing the budget file at most every
+// budgetSaveInterval.
+func (b *apiBudget) record(token, resource string, q quota) {
+	if b == nil || q.limit == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.state.Quotas[tokenID(token)+"/"+resource] = budgetQuota{Remaining: q.remaining, Limit: q.limit, Reset: q.reset, Observed: now}
+	if now.Sub(b.saved) < budgetSaveInterval {
+		return
+	}
+	b.saved = now
+	if err := b.save(); err != nil {
+		fmt.Printf("failed to write budget file %q: %v\n", b.path, err)
+	}
+}
+
+// save merges the state into the budget file, keeping the latest observation
+// of each quota, since other jobs may have written it meanwhile.
+func (b *apiBudget) save() error {
+	merged := readBudgetState(b.path)
+	for key, q := range b.state.Quotas {
+		if q.Observed.After(merged.Quotas[key].Observed) {
+			merged.Quotas[key] = q
+		}
+	}
+	b.state = merged
+	data, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(b.path, data, 0o644)
+}
+
+// checkBudget returns errDeferred if the command is deferrable and every token
+// has less than $API_BUDGET_DEFER_PERCENT of its quota left for an API in the
+// current window, according to the budget file.
+func checkBudget(cmd string, tokens []string) error {
+	budget := openAPIBudget()
+	if budget == nil || !deferrableCommands[cmd] || len(tokens) == 0 {
+		return nil
+	}
+	threshold := defaultBudgetDeferPercent
+	if v := os.Getenv("API_BUDGET_DEFER_PERCENT"); v != "" {
+		percent, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid API_BUDGET_DEFER_PERCENT %q: %w", v, err)
+		}
+		threshold = percent
+	}
+
+	now := time.Now()
+	for _, resource := range []string{"core", "graphql"} {
+		low := true
+		var best budgetQuota
+		for _, token := range tokens {
+			q, ok := budget.state.Quotas[tokenID(token)+"/"+resource]
+			if !ok || !q.Reset.After(now) || q.Remaining*100 >= q.Limit*threshold {
+				// Unknown, reset since or above the threshold.
+				low = false
+				break
+			}
+			if q.Remaining > best.Remaining {
+				best = q
+			}
+		}
+		if low {
+			return fmt.Errorf("%w %s: %d of %d %s quota left until %s, below %d%%", errDeferred, cmd, best.Remaining, best.Limit, resource, best.Reset.Format(time.RFC3339), threshold)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckBudget(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute)
+	low := budgetQuota{Remaining: 1000, Limit: 5000, Reset: reset}
+	high := budgetQuota{Remaining: 4000, Limit: 5000, Reset: reset}
+	for _, tc := range []struct {
+		name    string
+		noFile  bool
+		cmd     string
+		percent string
+		quotas  map[string]budgetQuota
+		want    error
+		wantErr bool
+	}{{
+		name:   "no budget file",
+		noFile: true,
+		cmd:    "report",
+	}, {
+		name:   "not deferrable",
+		cmd:    "sync",
+		quotas: map[string]budgetQuota{"a/graphql": low, "b/graphql": low},
+	}, {
+		name:   "all tokens low",
+		cmd:    "report",
+		quotas: map[string]budgetQuota{"a/graphql": low, "b/graphql": low},
+		want:   errDeferred,
+	}, {
+		name:   "one token above",
+		cmd:    "report",
+		quotas: map[string]budgetQuota{"a/graphql": low, "b/graphql": high},
+	}, {
+		name:   "one token unknown",
+		cmd:    "report",
+		quotas: map[string]budgetQuota{"a/core": low},
+	}, {
+		name:   "reset since",
+		cmd:    "report",
+		quotas: map[string]budgetQuota{"a/core": {Remaining: 0, Limit: 5000, Reset: time.Now().Add(-time.Minute)}, "b/core": low},
+	}, {
+		name:    "below a lower threshold",
+		cmd:     "report",
+		percent: "10",
+		quotas:  map[string]budgetQuota{"a/graphql": low, "b/graphql": low},
+	}, {
+		name:    "invalid threshold",
+		cmd:     "report",
+		percent: "ten",
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var path string
+			if !tc.noFile {
+				path = filepath.Join(t.TempDir(), "budget.json")
+				state := budgetState{Quotas: map[string]budgetQuota{}}
+				for key, q := range tc.quotas {
+					// Keys name the test tokens, stored under their ID.
+					token, resource := key[:1], key[2:]
+					state.Quotas[tokenID(token)+"/"+resource] = q
+				}
+				data, err := json.Marshal(state)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, data, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("API_BUDGET_FILE", path)
+			t.Setenv("API_BUDGET_DEFER_PERCENT", tc.percent)
+
+			err := checkBudget(tc.cmd, []string{"a", "b"})
+			switch {
+			case tc.wantErr:
+				if err == nil || errors.Is(err, errDeferred) {
+					t.Errorf("checkBudget() = %v, want a configuration error", err)
+				}
+			case !errors.Is(err, tc.want):
+				t.Errorf("checkBudget() = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// Values of changelogEntry.Action in addition to the audit actions.
+const (
+	// changelogComment is a comment posted on an issue or PR.
+	changelogComment = "comment"
+	// changelogSync is the sync of an item as a whole, only recorded when it fails.
+	changelogSync = "sync"
+)
+
+// Values of changelogEntry.Outcome.
+const (
+	outcomeOK      = "ok"
+	outcomeFailed  = "failed"
+	outcomeSkipped = "skipped"
+)
+
+// changelogEntry records an action performed by a run and its outcome.
+type changelogEntry struct {
+	auditEntry
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
+
+// changelog collects the actions of a run, written as a single JSON artifact at
+// the end of the run. Unlike the audit log, it is per run and also records
+// comments and failures.
+type changelog struct {
+	mu      sync.Mutex
+	start   time.Time
+	entries []changelogEntry
+}
+
+// record adds the entry with the outcome of err. It is a no-op on a nil changelog.
+func (l *changelog) record(entry auditEntry, err error) {
+	if l == nil {
+		return
+	}
+	entry.Time = time.Now().UTC()
+	e := changelogEntry{auditEntry: entry, Outcome: outcomeOK}
+	if err != nil {
+		e.Outcome, e.Error = outcomeFailed, err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, e)
+}
+
+// skip adds the entry as skipped for the given reason. It is a no-op on a nil
+// changelog.
+func (l *changelog) skip(entry auditEntry, reason string) {
+	if l == nil {
+		return
+	}
+	entry.Time = time.Now().UTC()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, changelogEntry{auditEntry: entry, Outcome: outcomeSkipped, Error: reason})
+}
+
+// write writes the changelog to path. runErr is the error the run ended with,
+// if any.
+func (l *changelog) write(path, profileName string, runErr error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	artifact := struct {
+		Profile string           `json:"profile"`
+		Start   time.Time        `json:"start"`
+		End     time.Time        `json:"end"`
+		Success bool             `json:"success"`
+		Error   string           `json:"error,omitempty"`
+		Actions []changelogEntry `json:"actions"`
+	}{
+		Profile: profileName,
+		Start:   l.start.UTC(),
+		End:     time.Now().UTC(),
+		Success: runErr == nil,
+		Actions: l.entries,
+	}
+	if runErr != nil {
+		artifact.Error = runErr.Error()
+	}
+	if artifact.Actions == nil {
+		artifact.Actions = []changelogEntry{}
+	}
+
+	data, err := json.MarshalIndent(artifact, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
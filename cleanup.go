@@ -0,0 +1,156 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// boardScope is the set of items a profile imports: the repositories searched
+// by each of its sources, including the API review queue, and the labels of
+// the sources.
+type boardScope struct {
+	sources []source
+	// repos are the owner/name repositories of each source, lowercased.
+	repos []map[string]bool
+}
+
+func (c *ghClient) loadBoardScope(ctx context.Context, prof profile) (*boardScope, error) {
+	sources := prof.Sources
+	if prof.APIReview != nil {
+		sources = append(sources[:len(sources):len(sources)], prof.APIReview.source())
+	}
+	scope := &boardScope{sources: sources}
+	for _, src := range sources {
+		repos, err := c.listSourceRepos(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		included := map[string]bool{}
+		for _, repo := range repos {
+			if src.includesRepo(repo) {
+				included[strings.ToLower(repo.GetFullName())] = true
+			}
+		}
+		scope.repos = append(scope.repos, included)
+	}
+	return scope, nil
+}
+
+// includes reports whether an item of the repository, in owner/name form,
+// carrying the labels is imported by one of the sources.
+func (b *boardScope) includes(repository string, labels []string) bool {
+	_, ok := b.sourceOf(repository, labels)
+	return ok
+}
+
+// sourceOf returns the first source importing an item of the repository
+// carrying the labels.
+func (b *boardScope) sourceOf(repository string, labels []string) (source, bool) {
+	have := stringSet(labels)
+	for i, src := range b.sources {
+		if !b.repos[i][strings.ToLower(repository)] {
+			continue
+		}
+		matches := true
+		for _, label := range src.Labels {
+			matches = matches && have[label]
+		}
+		if matches {
+			return src, true
+		}
+	}
+	return source{}, false
+}
+
+// sourceForRepo returns the first source importing items of the repository, in
+// owner/name form.
+func (b *boardScope) sourceForRepo(repository string) (source, bool) {
+	for i, src := range b.sources {
+		if b.repos[i][strings.ToLower(repository)] {
+			return src, true
+		}
+	}
+	return source{}, false
+}
+
+// isOrphan reports whether the board item is an issue or PR the profile does
+// not import, e.g. one added by hand or by a past bug. Draft issues only exist
+// on the board and are never orphans.
+func (b *boardScope) isOrphan(item *projectItem) bool {
+	switch item.Type {
+	case githubql.ProjectV2ItemTypeIssue, githubql.ProjectV2ItemTypePullRequest:
+		return !b.includes(item.Repository, item.Labels)
+	}
+	return false
+}
+
+// runCleanup removes the items that do not belong on the board.
+func runCleanup(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("cleanup", flag.ExitOnError)
+	common.register(fs)
+	orphans := fs.Bool("orphans", false, "remove issues and PRs that none of the profile's sources import")
+	dryRun := fs.Bool("dry-run", false, "only print the items that would be removed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !*orphans {
+		return fmt.Errorf("nothing to clean up, set --orphans")
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	scope, err := client.loadBoardScope(ctx, prof)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	removed := 0
+	for _, item := range items {
+		if !scope.isOrphan(item) {
+			continue
+		}
+		removed++
+		if *dryRun {
+			fmt.Printf("would remove %s (%s)\n", item.URL, item.Status)
+			continue
+		}
+		fmt.Printf("removing %s (%s) from project\n", item.URL, item.Status)
+		if err := client.deleteProjectV2Item(ctx, project, item); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%d of %d items do not belong on %q\n", removed, len(items), project.Title)
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "testing"
+
+func TestBoardScopeIncludes(t *testing.T) {
+	scope := &boardScope{
+		sources: []source{
+			{Org: "kubernetes", Labels: []string{"sig/auth"}},
+			{Org: "kubernetes-sigs"},
+		},
+		repos: []map[string]bool{
+			{"kubernetes/kubernetes": true},
+			{"kubernetes-sigs/secrets-store-csi-driver": true},
+		},
+	}
+	for _, tc := range []struct {
+		repository string
+		labels     []string
+		want       bool
+	}{
+		{"kubernetes/kubernetes", []string{"kind/bug", "sig/auth"}, true},
+		{"kubernetes/kubernetes", []string{"sig/node"}, false},
+		{"kubernetes/kubernetes", nil, false},
+		// Repositories compare case-insensitively.
+		{"Kubernetes-SIGs/Secrets-Store-CSI-Driver", nil, true},
+		{"kubernetes-sigs/kind", nil, false},
+	} {
+		if got := scope.includes(tc.repository, tc.labels); got != tc.want {
+			t.Errorf("includes(%q, %q) = %v, want %v", tc.repository, tc.labels, got, tc.want)
+		}
+	}
+}
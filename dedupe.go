@@ -0,0 +1,94 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// getItemFieldValues returns the current custom field values of the item.
+func (c *ghClient) getItemFieldValues(ctx context.Context, itemID githubql.ID) (map[string]string, error) {
+	var query struct {
+		Node struct {
+			ProjectV2Item struct {
+				FieldValues itemFieldValues `graphql:"fieldValues(first: 50)"`
+			} `graphql:"... on ProjectV2Item"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubql.ID(itemID),
+	}
+	if err := c.v4Client.Query(ctx, &query, variables); err != nil {
+		return nil, err
+	}
+	return query.Node.ProjectV2Item.FieldValues.values(), nil
+}
+
+// skipStaleMutation re-reads the field of the item right before it is set to
+// want, empty meaning cleared, and reports whether the mutation should be
+// skipped. It is skipped when the field already has the wanted value, or when it
+// changed since the item was read, which means that a concurrent run or a human
+// got there first and this run's decision is based on stale data. Adding items
+// needs no such check, as adding content that is already on the board returns
+// the existing item.
+func (c *ghClient) skipStaleMutation(ctx context.Context, p *project, item *projectItem, fieldName, want string) (bool, error) {
+	if item.values == nil {
+		// Nothing to compare against.
+		return false, nil
+	}
+	if c.queue != nil {
+		// Queued updates are only made by the sync, for items it just fetched.
+		// Re-reading each of them would undo the batching.
+		return false, nil
+	}
+	current, err := c.getItemFieldValues(ctx, item.ID)
+	if err != nil {
+		return false, err
+	}
+	// Only this field is refreshed, other fields may have queued updates that
+	// are not visible yet.
+	read := item.values[fieldName]
+	if value, ok := current[fieldName]; ok {
+		item.values[fieldName] = value
+	} else {
+		delete(item.values, fieldName)
+	}
+	if fieldName == statusFieldName {
+		item.Status = current[fieldName]
+	}
+
+	switch current[fieldName] {
+	case want:
+		return true, nil
+	case read:
+		return false, nil
+	}
+	fmt.Printf("skipping update of %q on %s, changed from %q to %q since it was read\n", fieldName, item.URL, read, current[fieldName])
+	c.changelog.skip(auditEntry{
+		Action:  auditUpdate,
+		Project: p.Title,
+		ItemID:  fmt.Sprint(item.ID),
+		Content: item.URL,
+		Field:   fieldName,
+		Before:  current[fieldName],
+		After:   want,
+	}, "changed concurrently")
+	return true, nil
+}
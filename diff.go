@@ -0,0 +1,122 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+)
+
+// boardDiff are the changes between two snapshots of a board.
+type boardDiff struct {
+	Added   []snapshotItem
+	Removed []snapshotItem
+	Moved   []statusChange
+}
+
+// statusChange is an item that moved between statuses.
+type statusChange struct {
+	Item snapshotItem
+	From string
+}
+
+// diffSnapshots returns the changes from the old to the current snapshot.
+func diffSnapshots(old, cur *boardSnapshot) boardDiff {
+	previous := make(map[string]snapshotItem, len(old.Items))
+	for _, item := range old.Items {
+		previous[item.ID] = item
+	}
+
+	var d boardDiff
+	for _, item := range cur.Items {
+		before, ok := previous[item.ID]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, item)
+		case before.Status != item.Status:
+			d.Moved = append(d.Moved, statusChange{Item: item, From: before.Status})
+		}
+		delete(previous, item.ID)
+	}
+	for _, item := range old.Items {
+		if _, ok := previous[item.ID]; ok {
+			d.Removed = append(d.Removed, item)
+		}
+	}
+	return d
+}
+
+// runDiff prints the board changes since a stored snapshot.
+func runDiff(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	common.register(fs)
+	snapshotDir := fs.String("snapshot-dir", "", "directory holding the board snapshots written by sync")
+	since := fs.Duration("since", 0, "compare against the latest snapshot at least this old, e.g. 168h for the last week; defaults to the latest snapshot")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *snapshotDir == "" {
+		return fmt.Errorf("--snapshot-dir is required")
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	old, err := loadSnapshot(*snapshotDir, time.Now().Add(-*since))
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	d := diffSnapshots(old, newSnapshot(project, items))
+	fmt.Printf("# Changes to %q since %s\n", project.Title, old.Time.Format(time.RFC1123))
+	fmt.Printf("\n## Added (%d)\n\n", len(d.Added))
+	for _, item := range d.Added {
+		fmt.Printf("- %s %s (%s)\n", snapshotItemRef(item), item.Title, item.Status)
+	}
+	fmt.Printf("\n## Removed (%d)\n\n", len(d.Removed))
+	for _, item := range d.Removed {
+		fmt.Printf("- %s %s (%s)\n", snapshotItemRef(item), item.Title, item.Status)
+	}
+	fmt.Printf("\n## Moved (%d)\n\n", len(d.Moved))
+	for _, change := range d.Moved {
+		fmt.Printf("- %s %s: %q → %q\n", snapshotItemRef(change.Item), change.Item.Title, change.From, change.Item.Status)
+	}
+	return nil
+}
+
+// snapshotItemRef returns the URL of the item, or its ID for drafts.
+func snapshotItemRef(item snapshotItem) string {
+	if item.URL != "" {
+		return item.URL
+	}
+	return item.ID
+}
@@ -0,0 +1,47 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	old := &boardSnapshot{Items: []snapshotItem{
+		{ID: "1", Status: statusNeedsTriage},
+		{ID: "2", Status: statusNeedsTriage},
+		{ID: "3", Status: statusNeedsApprover},
+	}}
+	cur := &boardSnapshot{Items: []snapshotItem{
+		{ID: "1", Status: statusNeedsTriage},
+		{ID: "2", Status: statusWaitingOnAuthor},
+		{ID: "4", Status: statusNeedsTriage},
+	}}
+	want := boardDiff{
+		Added:   []snapshotItem{{ID: "4", Status: statusNeedsTriage}},
+		Removed: []snapshotItem{{ID: "3", Status: statusNeedsApprover}},
+		Moved:   []statusChange{{Item: snapshotItem{ID: "2", Status: statusWaitingOnAuthor}, From: statusNeedsTriage}},
+	}
+	if got := diffSnapshots(old, cur); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffSnapshots() = %+v, want %+v", got, want)
+	}
+
+	if got := diffSnapshots(cur, cur); !reflect.DeepEqual(got, boardDiff{}) {
+		t.Errorf("diffSnapshots() of identical snapshots = %+v, want no changes", got)
+	}
+}
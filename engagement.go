@@ -0,0 +1,104 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const (
+	// commentsFieldName is the name of the number field holding the number of
+	// comments on the item.
+	commentsFieldName = "Comments"
+	// daysSinceCommentFieldName is the name of the number field holding the
+	// days since the last comment by a human, or since the item was created
+	// if nobody commented.
+	daysSinceCommentFieldName = "Days since last comment"
+)
+
+// syncEngagement sets the comment count and days since the last human comment
+// fields, telling items with active discussion from silent ones.
+func (s *syncer) syncEngagement(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	if s.syncsField(commentsFieldName) {
+		if err := s.client.setNumberField(ctx, s.project, item, commentsFieldName, float64(issue.GetComments())); err != nil {
+			return err
+		}
+	}
+
+	if !s.syncsField(daysSinceCommentFieldName) {
+		return nil
+	}
+	last := issue.GetCreatedAt()
+	if issue.GetComments() > 0 {
+		owner, repo := issueRepo(issue)
+		commented, err := s.client.lastHumanComment(ctx, owner, repo, issue.GetNumber(), s.botAuthors)
+		if err != nil {
+			return err
+		}
+		if !commented.IsZero() {
+			last = commented
+		}
+	}
+	days := int(time.Since(last).Hours() / 24)
+	return s.client.setNumberField(ctx, s.project, item, daysSinceCommentFieldName, float64(days))
+}
+
+// lastHumanComment returns the time of the last comment on the issue by
+// someone other than the bots, or the zero time if there is none.
+func (c *ghClient) lastHumanComment(ctx context.Context, owner, repo string, number int, bots map[string]bool) (time.Time, error) {
+	return c.lastComment(ctx, owner, repo, number, func(login string) bool {
+		return !bots[login] && !strings.HasSuffix(login, "[bot]")
+	})
+}
+
+// lastComment returns the time of the last comment on the issue whose author
+// matches, or the zero time if there is none. Pages are read from the last
+// one, as the comments are listed oldest first.
+func (c *ghClient) lastComment(ctx context.Context, owner, repo string, number int, matches func(login string) bool) (time.Time, error) {
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: perPage},
+	}
+	first, resp, err := c.Issues.ListComments(ctx, owner, repo, number, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// LastPage is only set when there is more than one page.
+	for page := resp.LastPage; page > 1; page-- {
+		opts.Page = page
+		comments, _, err := c.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t := lastMatchingComment(comments, matches); !t.IsZero() {
+			return t, nil
+		}
+	}
+	return lastMatchingComment(first, matches), nil
+}
+
+func lastMatchingComment(comments []*github.IssueComment, matches func(login string) bool) time.Time {
+	for i := len(comments) - 1; i >= 0; i-- {
+		if matches(comments[i].GetUser().GetLogin()) {
+			return comments[i].GetCreatedAt()
+		}
+	}
+	return time.Time{}
+}
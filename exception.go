@@ -0,0 +1,66 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const (
+	// statusExceptions is the status of freeze exception requests, which need
+	// a decision before their deadline.
+	statusExceptions = "Exceptions"
+	// exceptionDeadlineFieldName is the name of the date field holding the
+	// deadline of a freeze exception request.
+	exceptionDeadlineFieldName = "Exception deadline"
+)
+
+// exceptionTitleRE matches the titles of freeze exception requests.
+var exceptionTitleRE = regexp.MustCompile(`(?i)\bexception request\b|\bfreeze exception\b|\[exception\]`)
+
+// isExceptionRequest reports whether the item is a freeze exception request,
+// from its title or one of the profile's exception labels.
+func (p profile) isExceptionRequest(issue *github.Issue) bool {
+	for _, label := range p.ExceptionLabels {
+		if hasLabel(issue, label) {
+			return true
+		}
+	}
+	return exceptionTitleRE.MatchString(issue.GetTitle())
+}
+
+// syncExceptionDeadline sets the Exception deadline field of exception requests
+// to the first deadline of the release schedule after the request was made,
+// i.e. the date the excepted work has to land by.
+func (s *syncer) syncExceptionDeadline(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	if s.schedule == nil || !s.profile.isExceptionRequest(issue) {
+		return nil
+	}
+	freezes, err := s.schedule.freezes()
+	if err != nil {
+		return err
+	}
+	for _, f := range freezes {
+		if f.Date.After(issue.GetCreatedAt()) {
+			return s.client.setDateField(ctx, s.project, item, exceptionDeadlineFieldName, f.Date)
+		}
+	}
+	return nil
+}
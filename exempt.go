@@ -0,0 +1,52 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// pruneExempt removes the items carrying one of the exempt labels from the
+// board, e.g. tracking issues that were imported before being labeled.
+func (s *syncer) pruneExempt(ctx context.Context) error {
+	if len(s.filter.exemptLabels) == 0 {
+		return nil
+	}
+	exempt := stringSet(s.filter.exemptLabels)
+
+	items, err := s.client.listProjectItems(ctx, s.project)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if owner, repo, err := splitRepo(item.Repository); err == nil && !s.filter.includesRepo(owner, repo) {
+			continue
+		}
+		for _, label := range item.Labels {
+			if !exempt[label] {
+				continue
+			}
+			fmt.Printf("removing %s, labeled %s, from project\n", item.URL, label)
+			if err := s.client.deleteProjectV2Item(ctx, s.project, item); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
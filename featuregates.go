@@ -0,0 +1,238 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// featureGateFiles are the files of kubernetes/kubernetes declaring feature gates
+// and their versioned specs. Not all of them exist on every release branch.
+var featureGateFiles = []string{
+	"pkg/features/kube_features.go",
+	"pkg/features/versioned_kube_features.go",
+	"staging/src/k8s.io/apiserver/pkg/features/kube_features.go",
+	"staging/src/k8s.io/apiserver/pkg/features/versioned_kube_features.go",
+}
+
+// kepRE matches the KEP reference in the doc comment of a feature gate, e.g.
+// "kep: https://kep.k8s.io/3331".
+var kepRE = regexp.MustCompile(`kep:\s*\S*/(\d+)`)
+
+// featureGate is a feature gate and the stages it went through.
+type featureGate struct {
+	Name string
+	// KEP is the number of the enhancement the gate belongs to, if known.
+	KEP    int
+	Stages []featureGateStage
+}
+
+// featureGateStage is the stage of a feature gate starting at a release.
+type featureGateStage struct {
+	// Minor is the minor version of the release the stage starts in, or 0 if
+	// the spec is not versioned.
+	Minor      int
+	PreRelease string
+}
+
+// current returns the latest stage of the gate.
+func (g *featureGate) current() featureGateStage {
+	if len(g.Stages) == 0 {
+		return featureGateStage{}
+	}
+	return g.Stages[len(g.Stages)-1]
+}
+
+// listFeatureGates returns the feature gates of kubernetes/kubernetes at ref.
+func (c *ghClient) listFeatureGates(ctx context.Context, ref string) ([]*featureGate, error) {
+	var files []*ast.File
+	fset := token.NewFileSet()
+	for _, path := range featureGateFiles {
+		content, _, resp, err := c.Repositories.GetContents(ctx, orgName, "kubernetes", path, &github.RepositoryContentGetOptions{Ref: ref})
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		src, err := content.GetContent()
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		files = append(files, file)
+	}
+	return parseFeatureGates(files), nil
+}
+
+// parseFeatureGates collects the gates declared as featuregate.Feature constants
+// and the stages of their specs.
+func parseFeatureGates(files []*ast.File) []*featureGate {
+	gates := map[string]*featureGate{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value := spec.(*ast.ValueSpec)
+				if !isSelector(value.Type, "featuregate", "Feature") {
+					continue
+				}
+				doc := value.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				for _, name := range value.Names {
+					gate := &featureGate{Name: name.Name}
+					if m := kepRE.FindStringSubmatch(doc.Text()); m != nil {
+						gate.KEP, _ = strconv.Atoi(m[1])
+					}
+					gates[name.Name] = gate
+				}
+			}
+		}
+	}
+
+	// Specs are map entries keyed by the gate, either local or qualified with
+	// the package of the gate.
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			kv, ok := n.(*ast.KeyValueExpr)
+			if !ok {
+				return true
+			}
+			var gate *featureGate
+			switch key := kv.Key.(type) {
+			case *ast.Ident:
+				gate = gates[key.Name]
+			case *ast.SelectorExpr:
+				gate = gates[key.Sel.Name]
+			}
+			specs, ok := kv.Value.(*ast.CompositeLit)
+			if gate == nil || !ok {
+				return true
+			}
+			gate.Stages = append(gate.Stages, parseFeatureGateSpecs(specs)...)
+			return false
+		})
+	}
+
+	var list []*featureGate
+	for _, gate := range gates {
+		sort.SliceStable(gate.Stages, func(i, j int) bool { return gate.Stages[i].Minor < gate.Stages[j].Minor })
+		list = append(list, gate)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// parseFeatureGateSpecs parses either a single featuregate.FeatureSpec or a
+// list of versioned specs.
+func parseFeatureGateSpecs(lit *ast.CompositeLit) []featureGateStage {
+	if len(lit.Elts) > 0 {
+		if _, ok := lit.Elts[0].(*ast.KeyValueExpr); ok {
+			return []featureGateStage{parseFeatureGateSpec(lit)}
+		}
+	}
+	var stages []featureGateStage
+	for _, elt := range lit.Elts {
+		if spec, ok := elt.(*ast.CompositeLit); ok {
+			stages = append(stages, parseFeatureGateSpec(spec))
+		}
+	}
+	return stages
+}
+
+func parseFeatureGateSpec(lit *ast.CompositeLit) featureGateStage {
+	var stage featureGateStage
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Version":
+			// version.MustParse("1.29")
+			if call, ok := kv.Value.(*ast.CallExpr); ok && len(call.Args) == 1 {
+				if arg, ok := call.Args[0].(*ast.BasicLit); ok {
+					stage.Minor = parseMinor(strings.Trim(arg.Value, `"`))
+				}
+			}
+		case "PreRelease":
+			if sel, ok := kv.Value.(*ast.SelectorExpr); ok {
+				stage.PreRelease = sel.Sel.Name
+			}
+		}
+	}
+	return stage
+}
+
+// parseMinor returns the minor version of a 1.x version, or 0 if it can't be parsed.
+func parseMinor(v string) int {
+	_, minor, _ := strings.Cut(strings.TrimPrefix(v, "v"), ".")
+	n, _ := strconv.Atoi(minor)
+	return n
+}
+
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && isIdent(sel.X, pkg) && sel.Sel.Name == name
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// listSIGKEPs returns the numbers of the KEPs owned by the SIG in kubernetes/enhancements.
+func (c *ghClient) listSIGKEPs(ctx context.Context, sig string) (map[int]bool, error) {
+	_, dir, _, err := c.Repositories.GetContents(ctx, orgName, "enhancements", "keps/"+sig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keps := map[int]bool{}
+	for _, entry := range dir {
+		// KEP directories are named after the KEP number and title, e.g.
+		// "3331-structured-authentication-configuration".
+		number, _, _ := strings.Cut(entry.GetName(), "-")
+		if n, err := strconv.Atoi(number); err == nil {
+			keps[n] = true
+		}
+	}
+	return keps, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// Values of fieldMapping.From.
+const (
+	mappingFromLabel      = "label"
+	mappingFromMilestone  = "milestone"
+	mappingFromRepository = "repository"
+)
+
+// fieldMapping declares how a project field is derived from the item content,
+// so that new fields can be synced without code changes.
+type fieldMapping struct {
+	// Field is the name of the project field to set.
+	Field string `json:"field"`
+	// From is the item property the value is derived from, one of label,
+	// milestone or repository.
+	From string `json:"from"`
+	// Pattern is a regular expression the property must match. The first
+	// capture group, or the whole match if there is none, is the field value.
+	// For labels, the first matching label is used.
+	Pattern string `json:"pattern"`
+	// Map translates matched values into field values. Values without an
+	// entry are skipped. Empty means values are used as they are.
+	Map map[string]string `json:"map,omitempty"`
+	// Clear clears the field when nothing matches.
+	Clear bool `json:"clear,omitempty"`
+
+	re *regexp.Regexp
+}
+
+func (m *fieldMapping) compile() error {
+	switch m.From {
+	case mappingFromLabel, mappingFromMilestone, mappingFromRepository:
+	default:
+		return fmt.Errorf("field %q: unknown source %q, must be one of: %s, %s, %s", m.Field, m.From, mappingFromLabel, mappingFromMilestone, mappingFromRepository)
+	}
+
+	re, err := regexp.Compile(m.Pattern)
+	if err != nil {
+		return fmt.Errorf("field %q: %w", m.Field, err)
+	}
+	m.re = re
+	return nil
+}
+
+// value returns the field value for the issue, if any.
+func (m *fieldMapping) value(issue *github.Issue) (string, bool) {
+	var candidates []string
+	switch m.From {
+	case mappingFromLabel:
+		for _, label := range issue.Labels {
+			candidates = append(candidates, label.GetName())
+		}
+	case mappingFromMilestone:
+		candidates = append(candidates, issue.GetMilestone().GetTitle())
+	case mappingFromRepository:
+		owner, repo := issueRepo(issue)
+		candidates = append(candidates, owner+"/"+repo)
+	}
+
+	for _, candidate := range candidates {
+		match := m.re.FindStringSubmatch(candidate)
+		if match == nil {
+			continue
+		}
+		value := match[0]
+		if len(match) > 1 {
+			value = match[1]
+		}
+		if len(m.Map) == 0 {
+			return value, true
+		}
+		mapped, ok := m.Map[value]
+		return mapped, ok
+	}
+	return "", false
+}
+
+// syncFieldMappings applies the profile's field mappings to the item.
+func (s *syncer) syncFieldMappings(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	for i := range s.profile.FieldMappings {
+		m := &s.profile.FieldMappings[i]
+		if !s.syncsField(m.Field) {
+			continue
+		}
+
+		value, ok := m.value(issue)
+		field := s.project.fields[m.Field]
+		if ok && githubql.ProjectV2FieldType(field.DataType) == githubql.ProjectV2FieldTypeSingleSelect && !s.project.hasOption(m.Field, value) {
+			fmt.Printf("no %q option %q, skipping\n", m.Field, value)
+			continue
+		}
+		switch {
+		case ok:
+			if err := s.client.setFieldValue(ctx, s.project, item, m.Field, value); err != nil {
+				return err
+			}
+		case m.Clear:
+			if err := s.client.clearField(ctx, s.project, item, m.Field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
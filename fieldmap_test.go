@@ -0,0 +1,84 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+)
+
+func TestFieldMappingValue(t *testing.T) {
+	issue := &github.Issue{
+		RepositoryURL: github.String("https://api.github.com/repos/kubernetes-sigs/secrets-store-csi-driver"),
+		Milestone:     &github.Milestone{Title: github.String("v1.28")},
+		Labels: []*github.Label{
+			{Name: github.String("kind/bug")},
+			{Name: github.String("priority/important-soon")},
+			{Name: github.String("priority/critical-urgent")},
+		},
+	}
+	for _, tc := range []struct {
+		name    string
+		mapping fieldMapping
+		value   string
+		ok      bool
+	}{{
+		name:    "first matching label",
+		mapping: fieldMapping{From: mappingFromLabel, Pattern: `^priority/(.+)$`},
+		value:   "important-soon",
+		ok:      true,
+	}, {
+		name:    "whole match without capture group",
+		mapping: fieldMapping{From: mappingFromLabel, Pattern: `^kind/\w+$`},
+		value:   "kind/bug",
+		ok:      true,
+	}, {
+		name:    "no matching label",
+		mapping: fieldMapping{From: mappingFromLabel, Pattern: `^area/(.+)$`},
+	}, {
+		name:    "mapped value",
+		mapping: fieldMapping{From: mappingFromLabel, Pattern: `^priority/(.+)$`, Map: map[string]string{"important-soon": "P1"}},
+		value:   "P1",
+		ok:      true,
+	}, {
+		// Only the first matching label is mapped.
+		name:    "unmapped value",
+		mapping: fieldMapping{From: mappingFromLabel, Pattern: `^priority/(.+)$`, Map: map[string]string{"critical-urgent": "P0"}},
+	}, {
+		name:    "milestone",
+		mapping: fieldMapping{From: mappingFromMilestone, Pattern: `^v(\d+\.\d+)$`},
+		value:   "1.28",
+		ok:      true,
+	}, {
+		name:    "repository",
+		mapping: fieldMapping{From: mappingFromRepository, Pattern: `^kubernetes-sigs/(.+)$`},
+		value:   "secrets-store-csi-driver",
+		ok:      true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := tc.mapping
+			if err := m.compile(); err != nil {
+				t.Fatal(err)
+			}
+			value, ok := m.value(issue)
+			if value != tc.value || ok != tc.ok {
+				t.Errorf("value() = %q, %v, want %q, %v", value, ok, tc.value, tc.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const (
+	// unresolvedThreadsFieldName is the name of the number field holding the
+	// count of unresolved review threads on a PR.
+	unresolvedThreadsFieldName = "Unresolved Threads"
+	// sizeFieldName is the name of the single select field holding the PR size
+	// from its size/* label, e.g. "XS" for size/XS.
+	sizeFieldName = "Size"
+	// areaFieldName is the name of the single select field holding the area
+	// mapped from the item's area/* labels.
+	areaFieldName = "Area"
+	// lifecycleFieldName is the name of the single select field mirroring the
+	// item's lifecycle/* label.
+	lifecycleFieldName = "Lifecycle"
+	// repositoryGroupFieldName is the name of the single select field holding
+	// the repository name of subproject items.
+	repositoryGroupFieldName = "Repository group"
+	// removalReleaseFieldName is the name of the text field holding the release
+	// a deprecated API is removed in.
+	removalReleaseFieldName = "Removal release"
+	// membershipFieldName is the name of the single select field telling
+	// whether the item author is a member of the Kubernetes organization.
+	membershipFieldName = "Membership"
+	// upvotesFieldName is the name of the number field holding the count of 👍
+	// reactions on the item, a signal of community interest.
+	upvotesFieldName = "Upvotes"
+	// hasPRFieldName is the name of the single select field telling whether an
+	// issue has an open PR that fixes it.
+	hasPRFieldName = "Has PR"
+)
+
+// removalReleaseRE matches the removal release in the description of a
+// deprecation, e.g. "will be removed in v1.36".
+var removalReleaseRE = regexp.MustCompile(`(?i)\bremov(?:e|ed|al)\b[^\n]*?\b(v1\.\d+)\b`)
+
+// lifecycleOptions maps lifecycle/* label suffixes to options of the Lifecycle field.
+var lifecycleOptions = map[string]string{
+	"active": "Active",
+	"frozen": "Frozen",
+	"stale":  "Stale",
+	"rotten": "Rotten",
+}
+
+// syncFields updates the project fields derived from the item content.
+// Fields that are not defined on the project are skipped.
+func (s *syncer) syncFields(ctx context.Context, src source, item *projectItem, issue *github.Issue, pr *pullRequest) error {
+	if pr != nil && s.syncsField(unresolvedThreadsFieldName) {
+		if err := s.client.setNumberField(ctx, s.project, item, unresolvedThreadsFieldName, float64(pr.UnresolvedThreads)); err != nil {
+			return err
+		}
+	}
+
+	if size, ok := labelSuffix(issue, "size/"); ok && issue.IsPullRequest() && s.syncsField(sizeFieldName) {
+		if err := s.setOption(ctx, item, sizeFieldName, size); err != nil {
+			return err
+		}
+	}
+
+	if area, ok := s.profile.area(issue); ok && s.syncsField(areaFieldName) {
+		if err := s.setOption(ctx, item, areaFieldName, area); err != nil {
+			return err
+		}
+	}
+
+	if s.syncsField(lifecycleFieldName) {
+		if err := s.syncLifecycle(ctx, item, issue); err != nil {
+			return err
+		}
+	}
+
+	if src.RepositoryGroup && s.syncsField(repositoryGroupFieldName) {
+		if err := s.syncRepositoryGroup(ctx, item, issue); err != nil {
+			return err
+		}
+	}
+
+	if release, ok := removalRelease(issue); ok && s.syncsField(removalReleaseFieldName) {
+		if err := s.client.setFieldValue(ctx, s.project, item, removalReleaseFieldName, release); err != nil {
+			return err
+		}
+	}
+
+	if s.syncsField(membershipFieldName) {
+		member, err := s.client.isOrgMember(ctx, issue.GetUser().GetLogin())
+		if err != nil {
+			return err
+		}
+		option := "Non-member"
+		if member {
+			option = "Member"
+		}
+		if err := s.setOption(ctx, item, membershipFieldName, option); err != nil {
+			return err
+		}
+	}
+
+	if s.syncsField(upvotesFieldName) {
+		// The reaction counts are part of the issue listing, no extra request is needed.
+		if err := s.client.setNumberField(ctx, s.project, item, upvotesFieldName, float64(issue.GetReactions().GetPlusOne())); err != nil {
+			return err
+		}
+	}
+
+	if !issue.IsPullRequest() && s.syncsField(hasPRFieldName) {
+		linked, err := s.client.hasOpenLinkedPullRequest(ctx, issue.GetNodeID())
+		if err != nil {
+			return err
+		}
+		option := "No"
+		if linked {
+			option = "Yes"
+		}
+		if err := s.setOption(ctx, item, hasPRFieldName, option); err != nil {
+			return err
+		}
+	}
+
+	// The field is only set, as triagers may fill it in for items that do not
+	// reference their KEP.
+	if keps := referencedKEPs(issue); len(keps) > 0 && s.syncsField(kepFieldName) {
+		if err := s.client.setTextField(ctx, s.project, item, kepFieldName, strings.Join(keps, ", ")); err != nil {
+			return err
+		}
+	}
+
+	if s.syncsField(exceptionDeadlineFieldName) {
+		if err := s.syncExceptionDeadline(ctx, item, issue); err != nil {
+			return err
+		}
+	}
+
+	if s.syncsField(blockedFieldName) {
+		if err := s.syncBlocked(ctx, item, issue); err != nil {
+			return err
+		}
+	}
+
+	if err := s.syncEngagement(ctx, item, issue); err != nil {
+		return err
+	}
+
+	if err := s.syncAge(ctx, item, issue); err != nil {
+		return err
+	}
+
+	if s.syncsField(notesFieldName) {
+		if err := s.syncNotes(ctx, item, issue); err != nil {
+			return err
+		}
+	}
+
+	if s.syncsField(summaryFieldName) {
+		if err := s.syncSummary(ctx, item, issue); err != nil {
+			return err
+		}
+	}
+
+	if s.syncsField(suggestedKindFieldName) {
+		if err := s.syncSuggestedKind(ctx, item, issue); err != nil {
+			return err
+		}
+	}
+
+	return s.syncFieldMappings(ctx, item, issue)
+}
+
+// syncRepositoryGroup sets the Repository group field to the name of the item's
+// repository. Repositories without a matching option are skipped, since new
+// subprojects are added more often than the board is updated.
+func (s *syncer) syncRepositoryGroup(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	_, repo := issueRepo(issue)
+	return s.setOption(ctx, item, repositoryGroupFieldName, repo)
+}
+
+// syncLifecycle mirrors the lifecycle/* label into the Lifecycle field, clearing
+// it once the label is removed.
+func (s *syncer) syncLifecycle(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	lifecycle, ok := labelSuffix(issue, "lifecycle/")
+	if !ok {
+		return s.client.clearField(ctx, s.project, item, lifecycleFieldName)
+	}
+	option, ok := lifecycleOptions[lifecycle]
+	if !ok {
+		return nil
+	}
+	return s.setOption(ctx, item, lifecycleFieldName, option)
+}
+
+// removalRelease returns the release a deprecation targets for removal, taken
+// from its description.
+func removalRelease(issue *github.Issue) (string, bool) {
+	if !hasLabel(issue, "kind/deprecation") {
+		return "", false
+	}
+	m := removalReleaseRE.FindStringSubmatch(issue.GetBody())
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// area returns the Area option mapped from the first area label on the issue
+// that has a mapping in the profile.
+func (p profile) area(issue *github.Issue) (string, bool) {
+	for _, label := range issue.Labels {
+		if area, ok := p.Areas[label.GetName()]; ok {
+			return area, true
+		}
+	}
+	return "", false
+}
+
+// labelSuffix returns the remainder of the first label on the issue that starts
+// with prefix, e.g. "XS" for prefix "size/" and label size/XS.
+func labelSuffix(issue *github.Issue, prefix string) (string, bool) {
+	for _, label := range issue.Labels {
+		if strings.HasPrefix(label.GetName(), prefix) {
+			return strings.TrimPrefix(label.GetName(), prefix), true
+		}
+	}
+	return "", false
+}
+
+// isOrgMember reports whether the user is a member of the Kubernetes
+// organization. Results are cached, since the same authors show up on many items.
+func (c *ghClient) isOrgMember(ctx context.Context, login string) (bool, error) {
+	if member, ok := c.members[login]; ok {
+		return member, nil
+	}
+	member, _, err := c.Organizations.IsMember(ctx, orgName, login)
+	if err != nil {
+		return false, err
+	}
+	c.members[login] = member
+	return member, nil
+}
+
+// setOption sets the single select field on item to the option. Boards that do
+// not define the option, e.g. because it was added to the tool after the board
+// was set up, are warned about and the field is left alone.
+func (s *syncer) setOption(ctx context.Context, item *projectItem, fieldName, option string) error {
+	if !s.project.hasOption(fieldName, option) {
+		fmt.Printf("no %q option %q, skipping\n", fieldName, option)
+		return nil
+	}
+	return s.client.setSingleSelectField(ctx, s.project, item, fieldName, option)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSetOptionMissing(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(req graphqlRequest) string {
+		requests++
+		return statusValues(statusNeedsTriage)
+	})
+	s := &syncer{client: client, project: testProject(statusNeedsTriage)}
+	item := &projectItem{ID: "item", Status: statusNeedsTriage, values: map[string]string{statusFieldName: statusNeedsTriage}}
+
+	// Boards set up before the Deprecations status was added have no such option.
+	if err := s.setOption(context.Background(), item, statusFieldName, "Deprecations"); err != nil {
+		t.Fatalf("setOption() = %v, want the item to be skipped", err)
+	}
+	if requests != 0 || item.Status != statusNeedsTriage {
+		t.Errorf("setOption() sent %d requests and moved the item to %q, want it left alone", requests, item.Status)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// itemFilter restricts which issues and PRs are synced to the project.
+type itemFilter struct {
+	// milestone is the title of the milestone items must target, e.g. "v1.34".
+	// Empty means any milestone, including none.
+	milestone string
+	// pullRequestsOnly excludes issues.
+	pullRequestsOnly bool
+	// excludedAuthors are the logins whose items are excluded.
+	excludedAuthors map[string]bool
+	// automationPullRequests includes the PRs of excludedAuthors.
+	automationPullRequests bool
+	// repos restricts the sync to these repositories, in lowercased
+	// owner/name form, as GitHub names are case insensitive. Empty means all
+	// repositories of the sources.
+	repos map[string]bool
+	// labels are labels items must carry, in addition to the source labels.
+	labels []string
+	// exemptLabels are labels of items that are excluded.
+	exemptLabels []string
+	// createdAfter and createdBefore restrict the sync to items created in
+	// [createdAfter, createdBefore). Zero means unbounded.
+	createdAfter  time.Time
+	createdBefore time.Time
+}
+
+// includesRepo reports whether items of the repository pass the filter.
+func (f itemFilter) includesRepo(owner, repo string) bool {
+	return len(f.repos) == 0 || f.repos[strings.ToLower(owner+"/"+repo)]
+}
+
+// matches reports whether the issue passes the filter.
+func (f itemFilter) matches(issue *github.Issue) bool {
+	if f.milestone != "" && issue.GetMilestone().GetTitle() != f.milestone {
+		return false
+	}
+	if !f.createdAfter.IsZero() && issue.GetCreatedAt().Before(f.createdAfter) {
+		return false
+	}
+	if !f.createdBefore.IsZero() && !issue.GetCreatedAt().Before(f.createdBefore) {
+		return false
+	}
+	if f.pullRequestsOnly && !issue.IsPullRequest() {
+		return false
+	}
+	if f.excludedAuthors[issue.GetUser().GetLogin()] && !(f.automationPullRequests && issue.IsPullRequest()) {
+		return false
+	}
+	for _, label := range f.labels {
+		if !hasLabel(issue, label) {
+			return false
+		}
+	}
+	for _, label := range f.exemptLabels {
+		if hasLabel(issue, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// forSource returns the filter of the items of the source, which includes the
+// PRs of excluded authors when they are routed to an automation status.
+func (f itemFilter) forSource(src source) itemFilter {
+	f.automationPullRequests = src.AutomationStatus != ""
+	return f
+}
+
+// filterItems returns the issues that pass the filter.
+func (f itemFilter) filterItems(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if f.matches(issue) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// repoSet returns the set of the owner/name repositories, lowercased for
+// itemFilter.includesRepo.
+func repoSet(repos []string) map[string]bool {
+	set := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		set[strings.ToLower(repo)] = true
+	}
+	return set
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
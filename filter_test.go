@@ -0,0 +1,41 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "testing"
+
+func TestIncludesRepo(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		repos       []string
+		owner, repo string
+		want        bool
+	}{
+		{name: "no repos", owner: "kubernetes", repo: "kubernetes", want: true},
+		{name: "listed", repos: []string{"kubernetes/kubernetes"}, owner: "kubernetes", repo: "kubernetes", want: true},
+		{name: "not listed", repos: []string{"kubernetes/kubernetes"}, owner: "kubernetes", repo: "enhancements"},
+		{name: "listed in another case", repos: []string{"Kubernetes-SIGs/Secrets-Store-CSI-Driver"}, owner: "kubernetes-sigs", repo: "secrets-store-csi-driver", want: true},
+		{name: "named in another case", repos: []string{"kubernetes-sigs/secrets-store-csi-driver"}, owner: "kubernetes-sigs", repo: "Secrets-Store-CSI-Driver", want: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := itemFilter{repos: repoSet(tc.repos)}
+			if got := f.includesRepo(tc.owner, tc.repo); got != tc.want {
+				t.Errorf("includesRepo(%q, %q) = %v, want %v", tc.owner, tc.repo, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+	"sigs.k8s.io/yaml"
+)
+
+// severityFieldName is the name of the project field holding the severity of
+// imported audit findings.
+const severityFieldName = "Severity"
+
+// finding is an entry of a third-party security audit report.
+type finding struct {
+	// ID is the identifier of the finding in the report, e.g. NCC-K8S-005.
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Severity string `json:"severity,omitempty"`
+	// Description is the markdown body of the item.
+	Description string `json:"description,omitempty"`
+}
+
+// runImportFindings adds the findings of an audit report to the board as
+// draft issues, or as issues opened in --issues-repo, in the initial status
+// and with their severity, so remediation is tracked like any other work.
+func runImportFindings(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("import-findings", flag.ExitOnError)
+	common.register(fs)
+	audit := fs.String("audit", "", "name of the audit the findings come from, e.g. \"2023 third-party security audit\"")
+	issuesRepo := fs.String("issues-repo", "", "open issues in this repository, in owner/name form, instead of adding draft issues")
+	dryRun := fs.Bool("dry-run", false, "only print the findings that would be imported")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: import-findings [flags] <findings.yaml|findings.csv>")
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	findings, err := readFindings(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	p, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	im := &findingImporter{client: client, p: p, prof: prof, audit: *audit, dryRun: *dryRun, drafts: map[string]bool{}}
+	// Draft issues belong to no source and start in the issue status of the
+	// first one, the profile's main source.
+	if len(prof.Sources) > 0 {
+		im.src = prof.Sources[0]
+	}
+	if *issuesRepo != "" {
+		im.issuesRepo = *issuesRepo
+		if im.owner, im.repo, err = splitRepo(*issuesRepo); err != nil {
+			return err
+		}
+		// The issues carry the labels of the source importing them, so that
+		// cleanup and validate do not take them for orphans.
+		scope, err := client.loadBoardScope(ctx, prof)
+		if err != nil {
+			return err
+		}
+		src, ok := scope.sourceForRepo(*issuesRepo)
+		if !ok {
+			return fmt.Errorf("--issues-repo %s is not imported by any source of profile %q", *issuesRepo, common.profileName)
+		}
+		im.src = src
+	} else {
+		items, err := client.listProjectItems(ctx, p)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if item.Type == githubql.ProjectV2ItemTypeDraftIssue {
+				im.drafts[item.Title] = true
+			}
+		}
+	}
+
+	for _, f := range findings {
+		if err := im.importFinding(ctx, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findingImporter adds findings to a board, as draft issues or as issues
+// opened in issuesRepo.
+type findingImporter struct {
+	client *ghClient
+	p      *project
+	prof   profile
+	// src is the source of issuesRepo, or the profile's first source for
+	// draft issues.
+	src    source
+	audit  string
+	dryRun bool
+
+	issuesRepo, owner, repo string
+	// drafts holds the titles of the draft issues on the board, including
+	// those added by this run, so that repeated findings are added once.
+	drafts map[string]bool
+}
+
+// importFinding adds f to the board in its initial status and with its
+// severity, unless it was imported before.
+func (im *findingImporter) importFinding(ctx context.Context, f finding) error {
+	title := fmt.Sprintf("%s: %s", f.ID, f.Title)
+	var b strings.Builder
+	if f.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(f.Description))
+	}
+	if im.audit != "" {
+		fmt.Fprintf(&b, "Finding %s of the %s.\n", f.ID, im.audit)
+	}
+	if f.Severity != "" {
+		fmt.Fprintf(&b, "Severity: %s\n", f.Severity)
+	}
+
+	var item *projectItem
+	var status string
+	if im.issuesRepo == "" {
+		if im.drafts[title] {
+			return nil
+		}
+		im.drafts[title] = true
+		fmt.Printf("adding draft %q\n", title)
+		if im.dryRun {
+			return nil
+		}
+		var err error
+		if item, err = im.client.addProjectV2DraftIssue(ctx, im.p, title, b.String()); err != nil {
+			return err
+		}
+		status = im.src.IssueStatus
+	} else {
+		// Closed issues count too, the finding may be remediated.
+		exists, err := im.client.hasIssue(ctx, im.issuesRepo, title)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+		fmt.Printf("opening issue %q in %s\n", title, im.issuesRepo)
+		if im.dryRun {
+			return nil
+		}
+		labels := im.src.Labels
+		issue, _, err := im.client.Issues.Create(ctx, im.owner, im.repo, &github.IssueRequest{
+			Title:  github.String(title),
+			Body:   github.String(b.String()),
+			Labels: &labels,
+		})
+		if err != nil {
+			return err
+		}
+		if item, err = im.client.addProjectV2ItemById(ctx, im.p, githubql.ID(issue.GetNodeID())); err != nil {
+			return err
+		}
+		status = im.prof.initialStatus(im.src, issue)
+	}
+
+	if status != "" {
+		if err := im.client.setSingleSelectField(ctx, im.p, item, statusFieldName, status); err != nil {
+			return err
+		}
+	}
+	switch {
+	case f.Severity == "" || !im.p.hasField(severityFieldName):
+	case im.p.hasOption(severityFieldName, f.Severity):
+		if err := im.client.setSingleSelectField(ctx, im.p, item, severityFieldName, f.Severity); err != nil {
+			return err
+		}
+	default:
+		fmt.Printf("warning: no %q option in the %s field for %s\n", f.Severity, severityFieldName, f.ID)
+	}
+	return nil
+}
+
+// readFindings reads the findings of a YAML file holding a list of findings, or
+// of a CSV file with a header naming the id, title, severity and description
+// columns.
+func readFindings(path string) ([]finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []finding
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		if len(records) == 0 {
+			return nil, nil
+		}
+		columns := map[string]int{}
+		for i, name := range records[0] {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		get := func(record []string, name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		for _, record := range records[1:] {
+			findings = append(findings, finding{
+				ID:          get(record, "id"),
+				Title:       get(record, "title"),
+				Severity:    get(record, "severity"),
+				Description: get(record, "description"),
+			})
+		}
+	} else if err := yaml.UnmarshalStrict(data, &findings); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	for i, f := range findings {
+		if f.ID == "" || f.Title == "" {
+			return nil, fmt.Errorf("reading %s: finding %d has no id or title", path, i+1)
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// newFindingsClient returns a client for a fake GitHub recording the number of
+// items added to the board and the statuses they are moved to.
+func newFindingsClient(t *testing.T, adds *int, statuses *[]string) *ghClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/search/issues":
+			fmt.Fprint(w, `{"total_count": 0, "items": []}`)
+			return
+		case strings.HasSuffix(r.URL.Path, "/issues"):
+			var req github.IssueRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			issue := &github.Issue{
+				Title:         req.Title,
+				NodeID:        github.String("issue"),
+				RepositoryURL: github.String("https://api.github.com" + strings.TrimSuffix(r.URL.Path, "/issues")),
+			}
+			for _, label := range req.GetLabels() {
+				issue.Labels = append(issue.Labels, &github.Label{Name: github.String(label)})
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(issue)
+			return
+		}
+
+		var req graphqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch {
+		case strings.Contains(req.Query, "addProjectV2DraftIssue"):
+			*adds++
+			fmt.Fprint(w, `{"data": {"addProjectV2DraftIssue": {"projectItem": {"id": "item"}}}}`)
+		case strings.Contains(req.Query, "addProjectV2ItemById"):
+			*adds++
+			fmt.Fprint(w, `{"data": {"addProjectV2ItemById": {"item": {"id": "item", "createdAt": "2023-06-01T00:00:00Z", "fieldValues": {"nodes": []}, "content": {"url": "https://github.com/kubernetes/kubernetes/issues/1"}}}}}`)
+		case strings.Contains(req.Query, "updateProjectV2ItemFieldValue"):
+			var input struct {
+				FieldID string `json:"fieldId"`
+				Value   struct {
+					SingleSelectOptionID string `json:"singleSelectOptionId"`
+				} `json:"value"`
+			}
+			if err := json.Unmarshal(req.Variables["input"], &input); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if input.FieldID == "status" {
+				*statuses = append(*statuses, strings.TrimPrefix(input.Value.SingleSelectOptionID, "option-"))
+			}
+			fmt.Fprint(w, `{"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "item"}}}}`)
+		default:
+			fmt.Fprint(w, statusValues(""))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := &http.Client{Transport: rewriteTransport{target: target}}
+	return &ghClient{Client: github.NewClient(hc), v4Client: githubql.NewClient(hc), httpClient: hc, members: map[string]bool{}}
+}
+
+func TestImportFinding(t *testing.T) {
+	subprojects := source{IssueStatus: statusSubprojectsNeedsTriage}
+	policy := source{IssueStatus: statusNeedsTriage, Labels: []string{"wg/policy"}}
+	for _, tc := range []struct {
+		name       string
+		issuesRepo string
+		prof       profile
+		src        source
+		findings   []finding
+		adds       int
+		statuses   []string
+	}{{
+		name:     "draft in the main source's status",
+		src:      subprojects,
+		findings: []finding{{ID: "NCC-K8S-001", Title: "Token leak"}},
+		adds:     1,
+		statuses: []string{statusSubprojectsNeedsTriage},
+	}, {
+		name:     "repeated finding added once",
+		src:      subprojects,
+		findings: []finding{{ID: "NCC-K8S-001", Title: "Token leak"}, {ID: "NCC-K8S-001", Title: "Token leak"}},
+		adds:     1,
+		statuses: []string{statusSubprojectsNeedsTriage},
+	}, {
+		name:       "issue in the source's status",
+		issuesRepo: "kubernetes/kubernetes",
+		src:        subprojects,
+		findings:   []finding{{ID: "NCC-K8S-001", Title: "Token leak"}},
+		adds:       1,
+		statuses:   []string{statusSubprojectsNeedsTriage},
+	}, {
+		name:       "issue routed by label",
+		issuesRepo: "kubernetes/kubernetes",
+		prof:       profile{LabelStatuses: map[string]string{"wg/policy": "WG Policy - Needs Triage"}},
+		src:        policy,
+		findings:   []finding{{ID: "NCC-K8S-001", Title: "Token leak"}},
+		adds:       1,
+		statuses:   []string{"WG Policy - Needs Triage"},
+	}, {
+		name:       "issue routed by repository",
+		issuesRepo: "kubernetes/kubernetes",
+		prof:       profile{RepoStatuses: map[string]string{"kubernetes/kubernetes": statusNeedsApprover}},
+		src:        policy,
+		findings:   []finding{{ID: "NCC-K8S-001", Title: "Token leak"}},
+		adds:       1,
+		statuses:   []string{statusNeedsApprover},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var adds int
+			var statuses []string
+			im := &findingImporter{
+				client: newFindingsClient(t, &adds, &statuses),
+				p:      testProject(statusNeedsTriage, statusSubprojectsNeedsTriage, statusNeedsApprover, "WG Policy - Needs Triage"),
+				prof:   tc.prof,
+				src:    tc.src,
+				drafts: map[string]bool{},
+			}
+			if tc.issuesRepo != "" {
+				im.issuesRepo = tc.issuesRepo
+				im.owner, im.repo, _ = splitRepo(tc.issuesRepo)
+			}
+			for _, f := range tc.findings {
+				if err := im.importFinding(context.Background(), f); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if adds != tc.adds {
+				t.Errorf("added %d items, want %d", adds, tc.adds)
+			}
+			if !reflect.DeepEqual(statuses, tc.statuses) {
+				t.Errorf("moved to %q, want %q", statuses, tc.statuses)
+			}
+		})
+	}
+}
+
+func TestReadFindings(t *testing.T) {
+	for _, tc := range []struct {
+		name, file, content string
+		want                []finding
+		wantErr             bool
+	}{{
+		name: "YAML",
+		file: "findings.yaml",
+		content: `- id: NCC-K8S-001
+  title: Token leak
+  severity: High
+  description: Tokens are logged.
+- id: NCC-K8S-002
+  title: Weak default
+`,
+		want: []finding{
+			{ID: "NCC-K8S-001", Title: "Token leak", Severity: "High", Description: "Tokens are logged."},
+			{ID: "NCC-K8S-002", Title: "Weak default"},
+		},
+	}, {
+		name:    "unknown YAML field",
+		file:    "findings.yaml",
+		content: "- id: NCC-K8S-001\n  title: Token leak\n  status: fixed\n",
+		wantErr: true,
+	}, {
+		name:    "CSV",
+		file:    "findings.CSV",
+		content: "Title, ID ,Severity,Notes\nToken leak,NCC-K8S-001, High ,internal\nWeak default,NCC-K8S-002,,\n",
+		want: []finding{
+			{ID: "NCC-K8S-001", Title: "Token leak", Severity: "High"},
+			{ID: "NCC-K8S-002", Title: "Weak default"},
+		},
+	}, {
+		name:    "empty CSV",
+		file:    "findings.csv",
+		content: "",
+	}, {
+		name:    "no title",
+		file:    "findings.csv",
+		content: "id,severity\nNCC-K8S-001,High\n",
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.file)
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := readFindings(path)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("readFindings() error = %v, want error %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("readFindings() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
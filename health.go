@@ -0,0 +1,195 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/google/go-github/v48/github"
+)
+
+var (
+	// goDirectiveRE matches the go directive of a go.mod file.
+	goDirectiveRE = regexp.MustCompile(`(?m)^go\s+(\S+)`)
+	// kubernetesModuleRE matches the requirement of the Kubernetes libraries
+	// in a go.mod file, whose v0.x versions track Kubernetes 1.x.
+	kubernetesModuleRE = regexp.MustCompile(`(?m)^\s*(?:require\s+)?k8s\.io/(?:apimachinery|client-go)\s+v0\.(\d+)\.`)
+)
+
+// subprojectHealth is a row of the health dashboard.
+type subprojectHealth struct {
+	Repo          string
+	CI            string
+	LatestRelease string
+	GoVersion     string
+	Kubernetes    string
+	Alerts        string
+	Untriaged     int
+}
+
+// runHealth prints a dashboard of the health of each subproject for the SIG's
+// quarterly review.
+func runHealth(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("health", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("| Subproject | CI | Latest release | Go | Kubernetes | Open alerts | Untriaged issues |")
+	fmt.Println("| --- | --- | --- | --- | --- | --- | --- |")
+	for _, repo := range repos {
+		h, err := client.getSubprojectHealth(ctx, repo)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("| [%s](%s) | %s | %s | %s | %s | %s | %d |\n", h.Repo, repo.GetHTMLURL(), h.CI, h.LatestRelease, h.GoVersion, h.Kubernetes, h.Alerts, h.Untriaged)
+	}
+	return nil
+}
+
+func (c *ghClient) getSubprojectHealth(ctx context.Context, repo *github.Repository) (*subprojectHealth, error) {
+	owner, name, branch := repo.GetOwner().GetLogin(), repo.GetName(), repo.GetDefaultBranch()
+	h := &subprojectHealth{Repo: repo.GetFullName(), LatestRelease: "none", GoVersion: "n/a", Kubernetes: "n/a"}
+
+	ci, err := c.getCIState(ctx, owner, name, branch)
+	if err != nil {
+		return nil, err
+	}
+	h.CI = ci
+
+	release, err := c.getLatestRelease(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	if release != nil {
+		h.LatestRelease = fmt.Sprintf("%s (%s)", release.GetTagName(), release.GetPublishedAt().Format(dateFormat))
+	}
+
+	gomod, _, resp, err := c.Repositories.GetContents(ctx, owner, name, "go.mod", nil)
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+	case err != nil:
+		return nil, err
+	default:
+		content, err := gomod.GetContent()
+		if err != nil {
+			return nil, err
+		}
+		if m := goDirectiveRE.FindStringSubmatch(content); m != nil {
+			h.GoVersion = m[1]
+		}
+		if m := kubernetesModuleRE.FindStringSubmatch(content); m != nil {
+			h.Kubernetes = "1." + m[1]
+		}
+	}
+
+	alerts, err := c.countOpenAlerts(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	h.Alerts = alerts
+
+	issues, err := c.listIssuesAndPullRequests(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		if !issue.IsPullRequest() && !hasLabel(issue, "triage/accepted") {
+			h.Untriaged++
+		}
+	}
+	return h, nil
+}
+
+// getCIState returns the combined state of the status contexts and check runs
+// of the branch head: success, failure, pending or none.
+func (c *ghClient) getCIState(ctx context.Context, owner, repo, branch string) (string, error) {
+	status, _, err := c.Repositories.GetCombinedStatus(ctx, owner, repo, branch, &github.ListOptions{PerPage: perPage})
+	if err != nil {
+		return "", err
+	}
+	checks, _, err := c.Checks.ListCheckRunsForRef(ctx, owner, repo, branch, &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: perPage},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(status.Statuses) == 0 && checks.GetTotal() == 0 {
+		return "none", nil
+	}
+	state := "success"
+	if len(status.Statuses) > 0 {
+		state = status.GetState()
+	}
+	for _, run := range checks.CheckRuns {
+		switch {
+		case run.GetStatus() != "completed":
+			if state == "success" {
+				state = "pending"
+			}
+		case run.GetConclusion() == "failure" || run.GetConclusion() == "timed_out":
+			state = "failure"
+		}
+	}
+	return state, nil
+}
+
+// countOpenAlerts returns the number of open Dependabot alerts of the
+// repository, or n/a if they can't be read.
+func (c *ghClient) countOpenAlerts(ctx context.Context, owner, repo string) (string, error) {
+	var count int
+	opts := &github.ListAlertsOptions{
+		State:             github.String("open"),
+		ListCursorOptions: github.ListCursorOptions{PerPage: perPage},
+	}
+
+	for {
+		alerts, resp, err := c.Dependabot.ListRepoAlerts(ctx, owner, repo, opts)
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			// Alerts are disabled, or the token may not read them.
+			return "n/a", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		count += len(alerts)
+		if resp.After == "" {
+			break
+		}
+		opts.After = resp.After
+	}
+
+	return fmt.Sprint(count), nil
+}
@@ -0,0 +1,288 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// defaultIterationFieldName is the iteration field the release phases are
+// maintained in.
+const defaultIterationFieldName = "Iteration"
+
+// runSyncIterations creates an iteration per phase of the release in progress
+// on the board, and assigns the open items targeting the release to the current
+// phase. Existing iterations are only moved with --update-existing.
+func runSyncIterations(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("sync-iterations", flag.ExitOnError)
+	common.register(fs)
+	fieldName := fs.String("field", defaultIterationFieldName, "iteration field of the board to maintain")
+	dryRun := fs.Bool("dry-run", false, "only print the iterations and items that would be updated")
+	updateExisting := fs.Bool("update-existing", false, "also move the existing iterations of phases whose dates changed, which clears them from the items in them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	if cfg.ReleaseSchedule == nil {
+		return fmt.Errorf("no releaseSchedule configured")
+	}
+	schedule := cfg.ReleaseSchedule
+	phases, err := schedule.phases()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	field, ok := project.fields[*fieldName]
+	if !ok {
+		return fmt.Errorf("field %q not found in project %q", *fieldName, project.Title)
+	}
+
+	if !iterationsMatch(field.allIterations(), phases) {
+		missing, changed := planIterations(field.allIterations(), phases)
+		if len(changed) > 0 && !*updateExisting {
+			for _, phase := range changed {
+				fmt.Printf("WARNING: the %q iteration of %q does not match the schedule, which has it start on %s for %d days; set --update-existing to move it, which clears it from the items in it\n", phase.Title, *fieldName, phase.Start.Format(dateFormat), phaseDays(phase))
+			}
+			changed = nil
+		}
+		if len(missing)+len(changed) > 0 {
+			fmt.Printf("adding %d and moving %d %s iterations of %q\n", len(missing), len(changed), schedule.Release, *fieldName)
+			if !*dryRun {
+				if err := client.updateIterations(ctx, field, append(missing, changed...)); err != nil {
+					return err
+				}
+				if project.fields, err = client.getProjectFields(ctx, project.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	current := currentPhase(phases, time.Now())
+	if current == nil {
+		fmt.Printf("%s is released, not assigning items\n", schedule.Release)
+		return nil
+	}
+	phaseTitles := map[string]bool{}
+	for _, phase := range phases {
+		phaseTitles[phase.Title] = true
+	}
+
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			m, err := client.findMilestone(ctx, repo.GetOwner().GetLogin(), *repo.Name, schedule.Release)
+			if err != nil {
+				return err
+			}
+			if m == nil {
+				continue
+			}
+			items, err := client.listIssues(ctx, repo.GetOwner().GetLogin(), *repo.Name, &github.IssueListByRepoOptions{
+				Milestone: strconv.Itoa(m.GetNumber()),
+				Labels:    src.Labels,
+			})
+			if err != nil {
+				return err
+			}
+			for _, issue := range items {
+				if *dryRun {
+					fmt.Printf("would assign %s to %q unless it is in a later phase\n", issue.GetHTMLURL(), current.Title)
+					continue
+				}
+				item, err := client.addProjectV2ItemById(ctx, project, issue.GetNodeID())
+				if err != nil {
+					return err
+				}
+				// Items of earlier phases carry over, items already planned for
+				// a later phase or another release are left alone.
+				iteration := item.values[*fieldName]
+				if iteration != "" && (!phaseTitles[iteration] || phaseIndex(phases, iteration) >= phaseIndex(phases, current.Title)) {
+					continue
+				}
+				fmt.Printf("assigning %s to %q\n", item.URL, current.Title)
+				if err := client.setIterationField(ctx, project, item, *fieldName, current.Title); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// currentPhase returns the phase in progress at now, the first phase before the
+// release cycle starts, or nil once it is released.
+func currentPhase(phases []releasePhase, now time.Time) *releasePhase {
+	for i := range phases {
+		if now.Before(phases[i].End) {
+			return &phases[i]
+		}
+	}
+	return nil
+}
+
+func phaseIndex(phases []releasePhase, title string) int {
+	for i, phase := range phases {
+		if phase.Title == title {
+			return i
+		}
+	}
+	return -1
+}
+
+// iterationsMatch reports whether the iterations include every phase with its
+// exact window.
+func iterationsMatch(iterations []projectIteration, phases []releasePhase) bool {
+	for _, phase := range phases {
+		found := false
+		for _, iteration := range iterations {
+			if iteration.Title == phase.Title && iteration.StartDate == phase.Start.Format(dateFormat) && iteration.Duration == phaseDays(phase) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// planIterations returns the phases that have no iteration yet, and those whose
+// iteration has a different window.
+func planIterations(iterations []projectIteration, phases []releasePhase) (missing, changed []releasePhase) {
+	for _, phase := range phases {
+		found := false
+		for _, iteration := range iterations {
+			if iteration.Title != phase.Title {
+				continue
+			}
+			found = true
+			if iteration.StartDate != phase.Start.Format(dateFormat) || iteration.Duration != phaseDays(phase) {
+				changed = append(changed, phase)
+			}
+			break
+		}
+		if !found {
+			missing = append(missing, phase)
+		}
+	}
+	return missing, changed
+}
+
+func phaseDays(phase releasePhase) int {
+	return int(phase.End.Sub(phase.Start).Hours() / 24)
+}
+
+// updateIterations sets the iterations of the phases on the field, adding the
+// missing ones and moving those with the same title, and keeps the other
+// iterations as they are. The mutation is sent directly, as the iteration
+// configuration input is not part of the githubv4 schema this tool is built
+// with.
+func (c *ghClient) updateIterations(ctx context.Context, field *projectField, phases []releasePhase) error {
+	type iteration struct {
+		Title     string `json:"title"`
+		StartDate string `json:"startDate"`
+		Duration  int    `json:"duration"`
+	}
+	var iterations []iteration
+	for _, existing := range field.allIterations() {
+		if phaseIndex(phases, existing.Title) < 0 {
+			iterations = append(iterations, iteration{Title: existing.Title, StartDate: existing.StartDate, Duration: existing.Duration})
+		}
+	}
+	for _, phase := range phases {
+		iterations = append(iterations, iteration{Title: phase.Title, StartDate: phase.Start.Format(dateFormat), Duration: phaseDays(phase)})
+	}
+	sort.Slice(iterations, func(i, j int) bool { return iterations[i].StartDate < iterations[j].StartDate })
+
+	input := map[string]interface{}{
+		"fieldId": field.ID,
+		"iterationConfiguration": map[string]interface{}{
+			"startDate":  iterations[0].StartDate,
+			"duration":   iterations[0].Duration,
+			"iterations": iterations,
+		},
+	}
+	return c.postGraphQL(ctx, `mutation($input: UpdateProjectV2FieldInput!) { updateProjectV2Field(input: $input) { clientMutationId } }`, map[string]interface{}{"input": input})
+}
+
+// postGraphQL sends the query with the given variables, for queries that
+// cannot be expressed with v4Client.
+func (c *ghClient) postGraphQL(ctx context.Context, query string, variables map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphqlURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GraphQL request failed with status %s", resp.Status)
+	}
+
+	var result struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		var msgs []string
+		for _, e := range result.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return fmt.Errorf("GraphQL request failed: %s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
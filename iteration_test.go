@@ -0,0 +1,122 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2023, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+var testPhases = []releasePhase{
+	{Title: "v1.28 Enhancements", Start: day(time.May, 15), End: day(time.June, 16)},
+	{Title: "v1.28 Development", Start: day(time.June, 16), End: day(time.July, 18)},
+	{Title: "v1.28 Stabilization", Start: day(time.July, 18), End: day(time.August, 15)},
+}
+
+func TestCurrentPhase(t *testing.T) {
+	for _, tc := range []struct {
+		now  time.Time
+		want string
+	}{
+		{day(time.May, 1), "v1.28 Enhancements"},
+		{day(time.May, 20), "v1.28 Enhancements"},
+		// End is the first day of the next phase.
+		{day(time.June, 16), "v1.28 Development"},
+		{day(time.August, 14), "v1.28 Stabilization"},
+		{day(time.August, 15), ""},
+	} {
+		got := ""
+		if phase := currentPhase(testPhases, tc.now); phase != nil {
+			got = phase.Title
+		}
+		if got != tc.want {
+			t.Errorf("currentPhase(%s) = %q, want %q", tc.now.Format(dateFormat), got, tc.want)
+		}
+	}
+}
+
+func TestIterationsMatch(t *testing.T) {
+	matching := []projectIteration{
+		{Title: "v1.28 Enhancements", StartDate: "2023-05-15", Duration: 32},
+		{Title: "v1.28 Development", StartDate: "2023-06-16", Duration: 32},
+		{Title: "v1.28 Stabilization", StartDate: "2023-07-18", Duration: 28},
+	}
+	for _, tc := range []struct {
+		name       string
+		iterations []projectIteration
+		want       bool
+	}{{
+		name:       "matching",
+		iterations: matching,
+		want:       true,
+	}, {
+		name:       "extra iterations",
+		iterations: append([]projectIteration{{Title: "v1.27 Stabilization", StartDate: "2023-03-15", Duration: 28}}, matching...),
+		want:       true,
+	}, {
+		name:       "missing phase",
+		iterations: matching[:2],
+	}, {
+		name: "moved phase",
+		iterations: []projectIteration{
+			matching[0],
+			{Title: "v1.28 Development", StartDate: "2023-06-19", Duration: 29},
+			matching[2],
+		},
+	}, {
+		name: "different duration",
+		iterations: []projectIteration{
+			matching[0],
+			matching[1],
+			{Title: "v1.28 Stabilization", StartDate: "2023-07-18", Duration: 14},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := iterationsMatch(tc.iterations, testPhases); got != tc.want {
+				t.Errorf("iterationsMatch() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPlanIterations(t *testing.T) {
+	iterations := []projectIteration{
+		{Title: "v1.27 Stabilization", StartDate: "2023-03-15", Duration: 28},
+		{Title: "v1.28 Enhancements", StartDate: "2023-05-15", Duration: 32},
+		{Title: "v1.28 Development", StartDate: "2023-06-19", Duration: 29},
+	}
+	missing, changed := planIterations(iterations, testPhases)
+	if got := phaseTitles(missing); got != "v1.28 Stabilization" {
+		t.Errorf("missing = %q, want v1.28 Stabilization", got)
+	}
+	if got := phaseTitles(changed); got != "v1.28 Development" {
+		t.Errorf("changed = %q, want v1.28 Development", got)
+	}
+}
+
+func phaseTitles(phases []releasePhase) string {
+	var titles []string
+	for _, phase := range phases {
+		titles = append(titles, phase.Title)
+	}
+	return strings.Join(titles, ", ")
+}
@@ -0,0 +1,291 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultJiraLabel is the label of the Jira issues mirroring board items
+	// when the mapping sets none.
+	defaultJiraLabel = "sig-auth-board"
+	// defaultJiraIssueType is the type of the Jira issues when the mapping
+	// sets none.
+	defaultJiraIssueType = "Task"
+)
+
+// jiraUpstreamRE matches the line of the Jira issue description linking to the
+// board item content, which identifies the mirrored item.
+var jiraUpstreamRE = regexp.MustCompile(`(?m)^Upstream: (\S+)$`)
+
+// jiraMapping is the config of the Jira bridge, kept by each organization
+// mirroring the board.
+type jiraMapping struct {
+	// URL is the base URL of the Jira instance, e.g. https://example.atlassian.net.
+	URL string `json:"url"`
+	// Project is the key of the Jira project the items are exported to.
+	Project string `json:"project"`
+	// IssueType is the type of the created issues. Empty means defaultJiraIssueType.
+	IssueType string `json:"issueType,omitempty"`
+	// Label marks the issues mirroring board items. Empty means defaultJiraLabel.
+	Label string `json:"label,omitempty"`
+	// Statuses map board statuses to Jira statuses. Items in other statuses
+	// are exported but not transitioned.
+	Statuses map[string]string `json:"statuses,omitempty"`
+}
+
+// jiraIssue is a Jira issue mirroring a board item.
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Description string `json:"description"`
+		Status      struct {
+			Name string `json:"name"`
+		} `json:"status"`
+	} `json:"fields"`
+}
+
+// jiraClient calls the Jira REST API with the credentials of $JIRA_USER and
+// $JIRA_TOKEN.
+type jiraClient struct {
+	mapping jiraMapping
+	user    string
+	token   string
+}
+
+// runJiraExport mirrors the board items and their status into a Jira project:
+// items without a Jira issue get one, and the issues of items whose status
+// changed are transitioned to the mapped Jira status. The bridge is one-way,
+// changes made in Jira are not synced back.
+func runJiraExport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("jira-export", flag.ExitOnError)
+	common.register(fs)
+	mappingPath := fs.String("mapping", "", "path to the YAML Jira mapping config")
+	dryRun := fs.Bool("dry-run", false, "only print the changes that would be made in Jira")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *mappingPath == "" {
+		return fmt.Errorf("--mapping is required")
+	}
+	data, err := os.ReadFile(*mappingPath)
+	if err != nil {
+		return err
+	}
+	var mapping jiraMapping
+	if err := yaml.UnmarshalStrict(data, &mapping); err != nil {
+		return fmt.Errorf("parsing Jira mapping %q: %w", *mappingPath, err)
+	}
+	if mapping.URL == "" || mapping.Project == "" {
+		return fmt.Errorf("Jira mapping %q must set url and project", *mappingPath)
+	}
+	if mapping.IssueType == "" {
+		mapping.IssueType = defaultJiraIssueType
+	}
+	if mapping.Label == "" {
+		mapping.Label = defaultJiraLabel
+	}
+	jira := &jiraClient{mapping: mapping, user: os.Getenv("JIRA_USER"), token: os.Getenv("JIRA_TOKEN")}
+	if jira.user == "" || jira.token == "" {
+		return fmt.Errorf("JIRA_USER and JIRA_TOKEN must be set")
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	client := newClient(ctx, tokens)
+	p, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, p)
+	if err != nil {
+		return err
+	}
+	mirrored, err := jira.listIssues(ctx)
+	if err != nil {
+		return err
+	}
+
+	prefix := ""
+	if *dryRun {
+		prefix = "[dry-run] "
+	}
+	for _, item := range items {
+		if item.URL == "" {
+			continue
+		}
+		want := mapping.Statuses[item.Status]
+		issue, ok := mirrored[item.URL]
+		if !ok {
+			fmt.Printf("%screating Jira issue for %s\n", prefix, item.URL)
+			if *dryRun {
+				continue
+			}
+			if issue, err = jira.createIssue(ctx, item); err != nil {
+				return err
+			}
+		}
+		if want == "" || strings.EqualFold(issue.Fields.Status.Name, want) {
+			continue
+		}
+		fmt.Printf("%stransitioning %s of %s from %q to %q\n", prefix, issue.Key, item.URL, issue.Fields.Status.Name, want)
+		if *dryRun {
+			continue
+		}
+		if err := jira.transition(ctx, issue.Key, want); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listIssues returns the issues mirroring board items, by the URL of the item
+// content.
+func (j *jiraClient) listIssues(ctx context.Context) (map[string]*jiraIssue, error) {
+	jql := fmt.Sprintf("project = %q AND labels = %q", j.mapping.Project, j.mapping.Label)
+	issues := map[string]*jiraIssue{}
+	for start := 0; ; {
+		var page struct {
+			Total  int          `json:"total"`
+			Issues []*jiraIssue `json:"issues"`
+		}
+		query := url.Values{
+			"jql":        {jql},
+			"fields":     {"description,status"},
+			"startAt":    {fmt.Sprint(start)},
+			"maxResults": {"100"},
+		}
+		if err := j.do(ctx, http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			if m := jiraUpstreamRE.FindStringSubmatch(issue.Fields.Description); m != nil {
+				issues[m[1]] = issue
+			}
+		}
+		start += len(page.Issues)
+		if len(page.Issues) == 0 || start >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+// createIssue creates the Jira issue mirroring the item. The issue is read back,
+// as the creation response does not include the initial status the workflow
+// put it in.
+func (j *jiraClient) createIssue(ctx context.Context, item *projectItem) (*jiraIssue, error) {
+	summary := fmt.Sprintf("[%s#%d] %s", item.Repository, item.Number, item.Title)
+	description := fmt.Sprintf("Upstream: %s\n\nMirrored from the SIG Auth project board by sig-auth-tools, changes made here are not synced back.", item.URL)
+	body := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": j.mapping.Project},
+			"issuetype":   map[string]string{"name": j.mapping.IssueType},
+			"summary":     summary,
+			"description": description,
+			"labels":      []string{j.mapping.Label},
+		},
+	}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := j.do(ctx, http.MethodPost, "/rest/api/2/issue", body, &created); err != nil {
+		return nil, err
+	}
+	fmt.Printf("created Jira issue %s\n", created.Key)
+
+	issue := &jiraIssue{}
+	path := "/rest/api/2/issue/" + url.PathEscape(created.Key) + "?" + url.Values{"fields": {"description,status"}}.Encode()
+	if err := j.do(ctx, http.MethodGet, path, nil, issue); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// transition moves the issue to the status through the first transition
+// leading to it.
+func (j *jiraClient) transition(ctx context.Context, key, status string) error {
+	var transitions struct {
+		Transitions []struct {
+			ID string `json:"id"`
+			To struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "/transitions"
+	if err := j.do(ctx, http.MethodGet, path, nil, &transitions); err != nil {
+		return err
+	}
+	for _, t := range transitions.Transitions {
+		if strings.EqualFold(t.To.Name, status) {
+			return j.do(ctx, http.MethodPost, path, map[string]interface{}{"transition": map[string]string{"id": t.ID}}, nil)
+		}
+	}
+	return fmt.Errorf("no transition of %s to %q", key, status)
+}
+
+// do calls the Jira REST API, sending body and decoding the response into out
+// when they are not nil.
+func (j *jiraClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(j.mapping.URL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(j.user, j.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Jira %s %s failed with status %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJiraCreateIssue(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+			// The creation response only identifies the issue.
+			json.NewEncoder(w).Encode(map[string]string{"id": "10001", "key": "SA-1", "self": "https://example.atlassian.net/rest/api/2/issue/10001"})
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/SA-1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"key": "SA-1",
+				"fields": map[string]interface{}{
+					"description": "Upstream: https://github.com/kubernetes/kubernetes/issues/100",
+					"status":      map[string]string{"name": "To Do"},
+				},
+			})
+		default:
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	j := &jiraClient{mapping: jiraMapping{URL: srv.URL, Project: "SA", IssueType: defaultJiraIssueType, Label: defaultJiraLabel}}
+	issue, err := j.createIssue(context.Background(), &projectItem{Repository: "kubernetes/kubernetes", Number: 100, URL: "https://github.com/kubernetes/kubernetes/issues/100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Items mapped to the initial status must not be transitioned to it.
+	if issue.Key != "SA-1" || issue.Fields.Status.Name != "To Do" {
+		t.Errorf("createIssue() = %s in %q, want SA-1 in %q", issue.Key, issue.Fields.Status.Name, "To Do")
+	}
+}
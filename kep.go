@@ -0,0 +1,61 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// kepFieldName is the name of the text field holding the KEPs the item
+// references, e.g. "KEP-3299".
+const kepFieldName = "KEP"
+
+// kepReferenceRE matches references to KEPs: KEP-3299, KEP 3299,
+// kubernetes/enhancements#3299, enhancements issue URLs and KEP directories
+// such as keps/sig-auth/3299-kms-v2-improvements.
+var kepReferenceRE = regexp.MustCompile(`(?i)\bKEP[- ]?(\d+)\b|kubernetes/enhancements#(\d+)\b|github\.com/kubernetes/enhancements/issues/(\d+)\b|keps/sig-[\w-]+/(\d+)-`)
+
+// referencedKEPs returns the KEPs the item references in its title or body,
+// sorted by number. Issues of kubernetes/enhancements are the KEP they track.
+func referencedKEPs(issue *github.Issue) []string {
+	numbers := map[int]bool{}
+	if owner, repo := issueRepo(issue); owner == "kubernetes" && repo == "enhancements" && !issue.IsPullRequest() {
+		numbers[issue.GetNumber()] = true
+	}
+	for _, m := range kepReferenceRE.FindAllStringSubmatch(issue.GetTitle()+"\n"+issue.GetBody(), -1) {
+		for _, group := range m[1:] {
+			if n, err := strconv.Atoi(group); err == nil {
+				numbers[n] = true
+			}
+		}
+	}
+
+	var sorted []int
+	for n := range numbers {
+		sorted = append(sorted, n)
+	}
+	sort.Ints(sorted)
+	var keps []string
+	for _, n := range sorted {
+		keps = append(keps, "KEP-"+strconv.Itoa(n))
+	}
+	return keps
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// suggestedKindFieldName is the name of the text field holding the prow
+// command applying the kind suggested for items without a kind/* label.
+const suggestedKindFieldName = "Suggested kind"
+
+// kindKeywords are the keywords suggesting each kind, in order of precedence
+// when several kinds match equally.
+var kindKeywords = []struct {
+	kind     string
+	keywords []string
+}{
+	{"flake", []string{"flake", "flaky", "flaking"}},
+	{"bug", []string{"panic", "crash", "nil pointer", "segfault", "regression", "broken", "unexpected", "fails", "error"}},
+	{"feature", []string{"proposal", "kep", "feature request", "support for", "add support", "allow"}},
+	{"documentation", []string{"docs", "documentation", "typo", "readme"}},
+	{"deprecation", []string{"deprecate", "deprecation"}},
+	{"cleanup", []string{"cleanup", "clean up", "refactor", "remove unused"}},
+	{"support", []string{"question", "how do i", "how to"}},
+}
+
+// suggestKind returns the kind suggested by the keywords of the item title and
+// body, or an empty string. Keywords in the title count twice.
+func suggestKind(issue *github.Issue) string {
+	title, body := strings.ToLower(issue.GetTitle()), strings.ToLower(issue.GetBody())
+	best, bestScore := "", 0
+	for _, k := range kindKeywords {
+		score := 0
+		for _, keyword := range k.keywords {
+			score += 2*strings.Count(title, keyword) + strings.Count(body, keyword)
+		}
+		if score > bestScore {
+			best, bestScore = k.kind, score
+		}
+	}
+	return best
+}
+
+// syncSuggestedKind sets the Suggested kind field to the /kind command for
+// items without a kind/* label, so triagers can copy it to apply the label,
+// and clears it once the item has one.
+func (s *syncer) syncSuggestedKind(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	if _, ok := labelSuffix(issue, "kind/"); ok {
+		return s.client.clearField(ctx, s.project, item, suggestedKindFieldName)
+	}
+	kind := suggestKind(issue)
+	if kind == "" {
+		return s.client.clearField(ctx, s.project, item, suggestedKindFieldName)
+	}
+	return s.client.setTextField(ctx, s.project, item, suggestedKindFieldName, "/kind "+kind)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+)
+
+func TestSuggestKind(t *testing.T) {
+	for _, tc := range []struct {
+		name, title, body string
+		want              string
+	}{
+		{name: "no keywords", title: "Service account tokens", want: ""},
+		{name: "title keyword", title: "kube-apiserver panic on malformed token", want: "bug"},
+		{name: "body keyword", title: "Webhook authenticator", body: "Proposal: add a timeout option.", want: "feature"},
+		{name: "case insensitive", title: "Fix TYPO in the README", want: "documentation"},
+		{name: "title counts twice", title: "Flaky TestTokenReview", body: "The test fails on arm64.", want: "flake"},
+		{name: "body outweighs title", title: "Cleanup", body: "How do I configure this? Is this a question for slack? How to set it?", want: "support"},
+		{name: "ties go to the first kind", title: "Regression: deprecation warning", want: "bug"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := &github.Issue{Title: github.String(tc.title), Body: github.String(tc.body)}
+			if got := suggestKind(issue); got != tc.want {
+				t.Errorf("suggestKind() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
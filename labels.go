@@ -0,0 +1,134 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// labelSpec is a label as defined in label_sync/labels.yaml of kubernetes/test-infra.
+type labelSpec struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+}
+
+// labelsFile is the part of label_sync/labels.yaml used to find the standard labels.
+type labelsFile struct {
+	Default struct {
+		Labels []labelSpec `json:"labels"`
+	} `json:"default"`
+}
+
+// listStandardLabels returns the labels every Kubernetes repository has, as
+// synced by label_sync.
+func (c *ghClient) listStandardLabels(ctx context.Context) ([]labelSpec, error) {
+	var file labelsFile
+	if err := c.getYAMLFile(ctx, orgName, "test-infra", "label_sync/labels.yaml", &file); err != nil {
+		return nil, err
+	}
+	return file.Default.Labels, nil
+}
+
+// listLabels returns the labels of the repository by name.
+func (c *ghClient) listLabels(ctx context.Context, owner, repo string) (map[string]*github.Label, error) {
+	allLabels := map[string]*github.Label{}
+	opts := &github.ListOptions{PerPage: perPage}
+
+	for {
+		labels, resp, err := c.Issues.ListLabels(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			allLabels[label.GetName()] = label
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allLabels, nil
+}
+
+// runSeedLabels creates or updates the seed labels in every subproject
+// repository, so that the board rules work the same everywhere.
+func runSeedLabels(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("seed-labels", flag.ExitOnError)
+	common.register(fs)
+	dryRun := fs.Bool("dry-run", false, "only print the labels that would be created or updated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	seed := cfg.SeedLabels
+	if len(seed) == 0 {
+		seed, err = client.listStandardLabels(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+		existing, err := client.listLabels(ctx, owner, name)
+		if err != nil {
+			return err
+		}
+		for _, spec := range seed {
+			label := &github.Label{
+				Name:        github.String(spec.Name),
+				Color:       github.String(strings.TrimPrefix(spec.Color, "#")),
+				Description: github.String(spec.Description),
+			}
+			current, ok := existing[spec.Name]
+			switch {
+			case !ok:
+				fmt.Printf("creating label %q in %s\n", spec.Name, repo.GetFullName())
+				if !*dryRun {
+					_, _, err = client.Issues.CreateLabel(ctx, owner, name, label)
+				}
+			case !strings.EqualFold(current.GetColor(), label.GetColor()) || current.GetDescription() != label.GetDescription():
+				fmt.Printf("updating label %q in %s\n", spec.Name, repo.GetFullName())
+				if !*dryRun {
+					_, _, err = client.Issues.EditLabel(ctx, owner, name, spec.Name, label)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const (
+	// statusAccepted is the status of items the SIG has accepted.
+	statusAccepted = "Accepted"
+	// removeStaleCommand is the prow command that removes lifecycle/stale.
+	removeStaleCommand = "/remove-lifecycle stale"
+)
+
+// removeStaleFromAccepted asks the lifecycle bot to remove lifecycle/stale from
+// items the SIG has accepted, so accepted work is not rotted away.
+func (s *syncer) removeStaleFromAccepted(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	if item.Status != statusAccepted || !hasLabel(issue, "lifecycle/stale") {
+		return nil
+	}
+
+	owner, repo := issueRepo(issue)
+	fmt.Printf("removing lifecycle/stale from accepted item %s/%s#%d\n", owner, repo, *issue.Number)
+	_, _, err := s.client.Issues.CreateComment(ctx, owner, repo, *issue.Number, &github.IssueComment{
+		Body: github.String(removeStaleCommand),
+	})
+	s.client.changelog.record(auditEntry{Action: changelogComment, Content: item.URL, After: removeStaleCommand}, err)
+	return err
+}
+
+// issueRepo returns the owner and name of the repository the issue belongs to.
+func issueRepo(issue *github.Issue) (owner, repo string) {
+	// RepositoryURL is of the form https://api.github.com/repos/{owner}/{repo}.
+	parts := strings.Split(issue.GetRepositoryURL(), "/")
+	if len(parts) < 2 {
+		return "", ""
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// defaultItemLimit is the maximum number of active items of a project.
+	// xref: https://docs.github.com/en/issues/planning-and-tracking-with-projects/learning-about-projects/about-projects#scope-and-limits
+	defaultItemLimit = 50000
+	// defaultItemWarningPercent is the share of the item limit at which the
+	// sync starts warning.
+	defaultItemWarningPercent = 90
+)
+
+// checkItemLimit warns when the board is close to its item limit, since adding
+// items fails once it is reached. Over the threshold, the policies are applied
+// before importing so that archived items make room for new ones.
+func (s *syncer) checkItemLimit(ctx context.Context) error {
+	limit, warnPercent := s.profile.ItemLimit, s.profile.ItemWarningPercent
+	if limit == 0 {
+		limit = defaultItemLimit
+	}
+	if warnPercent == 0 {
+		warnPercent = defaultItemWarningPercent
+	}
+
+	count, err := s.client.countProjectItems(ctx, s.project)
+	if err != nil {
+		return err
+	}
+	s.stats.boardItems = count
+	if count*100 < limit*warnPercent {
+		return nil
+	}
+
+	fmt.Printf("WARNING: project %q has %d of at most %d active items\n", s.project.Title, count, limit)
+	if len(s.profile.Policies) == 0 {
+		fmt.Println("configure an archive policy, or archive closed items on the board, to make room for new items")
+		return nil
+	}
+	if err := s.applyPolicies(ctx); err != nil {
+		return err
+	}
+
+	count, err = s.client.countProjectItems(ctx, s.project)
+	if err != nil {
+		return err
+	}
+	s.stats.boardItems = count
+	if count >= limit {
+		return fmt.Errorf("project %q is full with %d active items, archive items to make room for new ones", s.project.Title, count)
+	}
+	return nil
+}
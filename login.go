@@ -0,0 +1,172 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// loginScopes are the OAuth scopes the tool needs, see newClient.
+const loginScopes = "repo read:org project"
+
+// minPollInterval is the default and minimum interval between polls of the
+// device flow, and the amount it grows by on slow_down.
+// xref: https://www.rfc-editor.org/rfc/rfc8628#section-3.5
+const minPollInterval = 5 * time.Second
+
+// deviceCode is the response of the device authorization request.
+// xref: https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/authorizing-oauth-apps#device-flow
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+type accessTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	Error       string `json:"error"`
+	Interval    int    `json:"interval"`
+}
+
+// tokenCachePath returns the file the token obtained by login is cached in.
+func tokenCachePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sig-auth-tools", "token"), nil
+}
+
+// cachedToken returns the token cached by login, or an empty string.
+func cachedToken() string {
+	path, err := tokenCachePath()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// runLogin obtains a token through the OAuth device flow and caches it for
+// later runs.
+func runLogin(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ExitOnError)
+	clientID := fs.String("client-id", os.Getenv("SIG_AUTH_TOOLS_CLIENT_ID"), "client ID of the GitHub OAuth app to authorize, defaults to $SIG_AUTH_TOOLS_CLIENT_ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *clientID == "" {
+		return fmt.Errorf("--client-id is required")
+	}
+
+	var code deviceCode
+	if err := postForm(ctx, "https://github.com/login/device/code", url.Values{
+		"client_id": {*clientID},
+		"scope":     {loginScopes},
+	}, &code); err != nil {
+		return err
+	}
+	fmt.Printf("Open %s and enter the code %s\n", code.VerificationURI, code.UserCode)
+
+	interval := pollInterval(code.Interval, minPollInterval)
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var resp accessTokenResponse
+		if err := postForm(ctx, "https://github.com/login/oauth/access_token", url.Values{
+			"client_id":   {*clientID},
+			"device_code": {code.DeviceCode},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		}, &resp); err != nil {
+			return err
+		}
+
+		switch resp.Error {
+		case "":
+			return saveToken(resp.AccessToken)
+		case "authorization_pending":
+		case "slow_down":
+			interval = pollInterval(resp.Interval, interval+minPollInterval)
+		default:
+			return fmt.Errorf("login failed: %s", resp.Error)
+		}
+	}
+	return fmt.Errorf("login failed: the code expired")
+}
+
+// pollInterval returns the interval in seconds sent by the server, or min if it
+// is missing or shorter.
+func pollInterval(seconds int, min time.Duration) time.Duration {
+	if interval := time.Duration(seconds) * time.Second; interval > min {
+		return interval
+	}
+	return min
+}
+
+func saveToken(token string) error {
+	path, err := tokenCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return err
+	}
+	fmt.Printf("Logged in, token saved to %s\n", path)
+	return nil
+}
+
+// postForm posts the form and decodes the JSON response into v.
+func postForm(ctx context.Context, target string, form url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("POST %s failed with status %s", target, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
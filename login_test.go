@@ -0,0 +1,43 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollInterval(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		seconds int
+		min     time.Duration
+		want    time.Duration
+	}{
+		{name: "missing", min: 5 * time.Second, want: 5 * time.Second},
+		{name: "shorter", seconds: 1, min: 5 * time.Second, want: 5 * time.Second},
+		{name: "equal", seconds: 5, min: 5 * time.Second, want: 5 * time.Second},
+		{name: "longer", seconds: 15, min: 5 * time.Second, want: 15 * time.Second},
+		{name: "slowed down", seconds: 5, min: 10 * time.Second, want: 10 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := pollInterval(tc.seconds, tc.min); got != tc.want {
+				t.Errorf("pollInterval(%d, %v) = %v, want %v", tc.seconds, tc.min, got, tc.want)
+			}
+		})
+	}
+}
@@ -18,62 +18,286 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/google/go-github/v48/github"
 	githubql "github.com/shurcooL/githubv4"
-	"golang.org/x/oauth2"
 )
 
 const (
 	// perPage is the number of items to return per page.
 	perPage = 100
-	// orgName is the name of the GitHub organization to query.
+	// orgName is the name of the GitHub organization owning the project boards.
 	orgName = "kubernetes"
-	// projectName is the name of the GitHub project to query.
+	// projectName is the name of the GitHub project used by the default profile.
 	projectName = "SIG Auth"
 )
 
 type ghClient struct {
 	*github.Client
 	v4Client *githubql.Client
+	// httpClient is the authenticated client the API clients are built on.
+	httpClient *http.Client
+	// queue buffers field updates to send them in batches, nil if updates are
+	// sent immediately.
+	queue *updateQueue
+	// plan, if set, collects the field updates instead of making them.
+	plan *mutationPlan
+	// audit records board mutations, nil if disabled.
+	audit *auditLog
+	// changelog collects the actions of the run, nil if disabled.
+	changelog *changelog
+	// mutations is the number of board mutations performed.
+	mutations int
+	// members caches whether users are members of orgName, by login.
+	members map[string]bool
 }
 
 func main() {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
-	defer cancel()
-	// GITHUB_TOKEN is a personal access token with the following scopes:
-	// - repo (all)
-	// - read:org
-	// - project (all)
-	token := os.Getenv("GITHUB_TOKEN")
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := ghClient{Client: github.NewClient(tc), v4Client: githubql.NewClient(tc)}
-
-	projectID, err := client.getProjectID(ctx, orgName, projectName)
+	timeout, args, err := extractTimeout(os.Args[1:])
 	must(err)
 
-	repos, err := client.listRepos(ctx, orgName)
-	must(err)
+	// The command defaults to sync so that the tool can be run without arguments.
+	cmd := "sync"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cmd, args = args[0], args[1:]
+	}
 
-	for _, repo := range repos {
-		fmt.Printf("Looking for issues and PRs in %s/%s\n", orgName, *repo.Name)
+	// Interrupting a run cancels the context, which lets commands finish the
+	// mutation in progress instead of being killed half-way.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-		items, err := client.listIssuesAndPullRequests(ctx, orgName, *repo.Name, "sig/auth")
+	// The plugin and serve are long-running servers and triage an interactive
+	// session, all other commands are one-off runs.
+	if cmd != "plugin" && cmd != "serve" && cmd != "triage" {
+		switch {
+		case timeout > 0:
+		case cmd == "vulncheck":
+			// Each subproject is cloned and built.
+			timeout = 30 * time.Minute
+		case cmd == "backfill":
+			// Every closed item since --since is listed, and each PR fetched.
+			timeout = 30 * time.Minute
+		case cmd == "login":
+			// Device codes expire after 15 minutes.
+			timeout = 15 * time.Minute
+		default:
+			// Leaves room for the pacing of requests once the quota of the
+			// tokens runs low, see quota.delay.
+			timeout = 10 * time.Minute
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// The tokens are loaded once, as the gh CLI may be asked for one.
+	tokens := loadTokens()
+
+	// Scheduled jobs sharing the tokens leave the remaining quota to the sync
+	// and the plugin.
+	if err := checkBudget(cmd, tokens); err != nil {
+		if errors.Is(err, errDeferred) {
+			fmt.Println(err)
+			return
+		}
 		must(err)
+	}
+
+	switch cmd {
+	case "sync":
+		err = runSync(ctx, tokens, args)
+	case "report":
+		err = runReport(ctx, tokens, args)
+	case "tracking-issue":
+		err = runTrackingIssue(ctx, tokens, args)
+	case "diff":
+		err = runDiff(ctx, tokens, args)
+	case "weekly-report":
+		err = runWeeklyReport(ctx, tokens, args)
+	case "triage-party":
+		err = runTriageParty(ctx, tokens, args)
+	case "audit-teams":
+		err = runAuditTeams(ctx, tokens, args)
+	case "audit-branches":
+		err = runAuditBranches(ctx, tokens, args)
+	case "audit-repos":
+		err = runAuditRepos(ctx, tokens, args)
+	case "seed-labels":
+		err = runSeedLabels(ctx, tokens, args)
+	case "sync-milestones":
+		err = runSyncMilestones(ctx, tokens, args)
+	case "sync-iterations":
+		err = runSyncIterations(ctx, tokens, args)
+	case "health":
+		err = runHealth(ctx, tokens, args)
+	case "vulncheck":
+		err = runVulncheck(ctx, tokens, args)
+	case "plugin":
+		err = runPlugin(ctx, tokens, args)
+	case "login":
+		err = runLogin(ctx, args)
+	case "backfill":
+		err = runBackfill(ctx, tokens, args)
+	case "cleanup":
+		err = runCleanup(ctx, tokens, args)
+	case "validate":
+		err = runValidate(ctx, tokens, args)
+	case "triage":
+		err = runTriage(ctx, tokens, args)
+	case "serve":
+		err = runServe(ctx, tokens, args)
+	case "agenda":
+		err = runAgenda(ctx, tokens, args)
+	case "meeting-issue":
+		err = runMeetingIssue(ctx, tokens, args)
+	case "release-followups":
+		err = runReleaseFollowUps(ctx, tokens, args)
+	case "import-findings":
+		err = runImportFindings(ctx, tokens, args)
+	case "jira-export":
+		err = runJiraExport(ctx, tokens, args)
+	case "plan":
+		err = runPlan(ctx, tokens, args)
+	case "apply":
+		err = runApply(ctx, tokens, args)
+	default:
+		err = fmt.Errorf("unknown command %q, must be one of: sync, report, tracking-issue, diff, weekly-report, triage-party, audit-teams, audit-branches, audit-repos, seed-labels, sync-milestones, sync-iterations, health, vulncheck, plugin, login, backfill, cleanup, validate, plan, apply, triage, serve, agenda, meeting-issue, release-followups, import-findings, jira-export", cmd)
+	}
+	must(err)
+}
+
+// extractTimeout removes the --timeout flag, e.g. --timeout 30m, from the
+// arguments and returns its value, zero if it is not set. It is handled before
+// the command parses its flags, as the deadline of the run is set up by main.
+func extractTimeout(args []string) (time.Duration, []string, error) {
+	var timeout time.Duration
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "timeout" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return 0, nil, fmt.Errorf("flag needs an argument: --timeout")
+			}
+			i++
+			value = args[i]
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return 0, nil, fmt.Errorf("invalid --timeout %q, must be a positive duration such as 30m", value)
+		}
+		timeout = d
+	}
+	return timeout, rest, nil
+}
+
+// commonFlags are the flags shared by all commands.
+type commonFlags struct {
+	configPath  string
+	profileName string
+}
+
+func (f *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.configPath, "config", "", "path to a YAML config file, defaults to the built-in config")
+	fs.StringVar(&f.profileName, "profile", defaultProfile, "board profile from the config to use")
+}
+
+// load returns the config and the selected profile.
+func (f *commonFlags) load() (*config, profile, error) {
+	cfg, err := loadConfig(f.configPath)
+	if err != nil {
+		return nil, profile{}, err
+	}
+	prof, err := cfg.getProfile(f.profileName)
+	if err != nil {
+		return nil, profile{}, err
+	}
+	return cfg, prof, nil
+}
+
+// stringList is a flag holding a comma-separated list of values.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// dateFlag is a flag holding a date in YYYY-MM-DD form, in UTC.
+type dateFlag struct {
+	time.Time
+}
+
+func (d *dateFlag) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(dateFormat)
+}
 
-		fmt.Printf("found %d in repo %s/%s\n", len(items), orgName, *repo.Name)
-		for _, item := range items {
-			fmt.Printf("adding [%d] %s to project\n", *item.Number, *item.Title)
-			err := client.addProjectV2ItemById(ctx, projectID, *item.NodeID)
-			must(err)
+func (d *dateFlag) Set(value string) error {
+	t, err := time.Parse(dateFormat, value)
+	if err != nil {
+		return fmt.Errorf("invalid date %q, must be of the form YYYY-MM-DD", value)
+	}
+	d.Time = t
+	return nil
+}
+
+// daysFlag is a duration flag that also accepts a number of days, e.g. 7d.
+type daysFlag struct {
+	time.Duration
+}
+
+func (d *daysFlag) Set(value string) error {
+	if strings.HasSuffix(value, "d") {
+		n, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
 		}
+		d.Duration = time.Duration(n) * 24 * time.Hour
+		return nil
 	}
+	var err error
+	d.Duration, err = time.ParseDuration(value)
+	return err
+}
+
+func newClient(ctx context.Context, tokens []string) *ghClient {
+	// GITHUB_TOKEN, or each of the comma-separated GITHUB_TOKENS, is a personal
+	// access token with the following scopes:
+	// - repo (all)
+	// - read:org
+	// - project (all)
+	tc := &http.Client{Transport: newTokenRotator(http.DefaultTransport, tokens)}
+	return &ghClient{Client: github.NewClient(tc), v4Client: githubql.NewClient(tc), httpClient: tc, members: map[string]bool{}}
 }
 
 func (c *ghClient) listRepos(ctx context.Context, org string) ([]*github.Repository, error) {
@@ -97,13 +321,57 @@ func (c *ghClient) listRepos(ctx context.Context, org string) ([]*github.Reposit
 	return allRepos, nil
 }
 
+// listSourceRepos returns the repositories searched by the source: its Repos if
+// set, otherwise those of its organization. Callers still filter them with
+// source.includesRepo.
+func (c *ghClient) listSourceRepos(ctx context.Context, src source) ([]*github.Repository, error) {
+	if len(src.Repos) == 0 {
+		return c.listRepos(ctx, src.Org)
+	}
+	var repos []*github.Repository
+	for _, nameWithOwner := range src.Repos {
+		owner, name, err := splitRepo(nameWithOwner)
+		if err != nil {
+			return nil, err
+		}
+		repo, _, err := c.Repositories.Get(ctx, owner, name)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+// listSubprojectRepos returns the repositories of the profile's sources that
+// are restricted to topics, i.e. the subproject repositories.
+func (c *ghClient) listSubprojectRepos(ctx context.Context, prof profile) ([]*github.Repository, error) {
+	var subprojects []*github.Repository
+	for _, src := range prof.Sources {
+		if len(src.Topics) == 0 {
+			continue
+		}
+		repos, err := c.listSourceRepos(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			if src.includesRepo(repo) {
+				subprojects = append(subprojects, repo)
+			}
+		}
+	}
+	return subprojects, nil
+}
+
 func (c *ghClient) listIssuesAndPullRequests(ctx context.Context, owner, repo string, labels ...string) ([]*github.Issue, error) {
+	return c.listIssues(ctx, owner, repo, &github.IssueListByRepoOptions{Labels: labels})
+}
+
+func (c *ghClient) listIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, error) {
 	var allIssues []*github.Issue
-	opts := &github.IssueListByRepoOptions{
-		Labels: labels,
-		ListOptions: github.ListOptions{
-			PerPage: perPage,
-		},
+	opts.ListOptions = github.ListOptions{
+		PerPage: perPage,
 	}
 
 	for {
@@ -158,23 +426,6 @@ func (c *ghClient) getProjectID(ctx context.Context, org, name string) (githubql
 	return nil, fmt.Errorf("project %q not found", name)
 }
 
-func (c *ghClient) addProjectV2ItemById(ctx context.Context, projectID, contentID githubql.ID) error {
-	// xref: https://docs.github.com/en/issues/planning-and-tracking-with-projects/automating-your-project/using-the-api-to-manage-projects#adding-an-item-to-a-project
-	var mutation struct {
-		AddProjectV2ItemById struct {
-			Item struct {
-				ID githubql.ID `graphql:"id"`
-			} `graphql:"item"`
-		} `graphql:"addProjectV2ItemById(input: $input)"`
-	}
-	input := githubql.AddProjectV2ItemByIdInput{
-		ProjectID: projectID,
-		ContentID: contentID,
-	}
-
-	return c.v4Client.Mutate(ctx, &mutation, input, nil)
-}
-
 func must(err error) {
 	if err != nil {
 		panic(err)
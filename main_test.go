@@ -0,0 +1,52 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		args    []string
+		timeout time.Duration
+		rest    []string
+		wantErr bool
+	}{
+		{args: []string{"sync", "--dry-run"}, rest: []string{"sync", "--dry-run"}},
+		{args: []string{"--timeout", "30m", "sync"}, timeout: 30 * time.Minute, rest: []string{"sync"}},
+		{args: []string{"report", "untriaged", "-timeout=1h"}, timeout: time.Hour, rest: []string{"report", "untriaged"}},
+		{args: []string{"sync", "--", "--timeout", "1h"}, rest: []string{"sync", "--", "--timeout", "1h"}},
+		{args: []string{"sync", "--timeout"}, wantErr: true},
+		{args: []string{"sync", "--timeout=forever"}, wantErr: true},
+		{args: []string{"sync", "--timeout=-1m"}, wantErr: true},
+	} {
+		timeout, rest, err := extractTimeout(tc.args)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("extractTimeout(%q) error = %v, want error %v", tc.args, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr {
+			continue
+		}
+		if timeout != tc.timeout || !reflect.DeepEqual(rest, tc.rest) {
+			t.Errorf("extractTimeout(%q) = %s, %q, want %s, %q", tc.args, timeout, rest, tc.timeout, tc.rest)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// meetingTimeFormat is the format of triageMeeting.First.
+	meetingTimeFormat = "2006-01-02 15:04"
+	// defaultMeetingTitle is the title of the meeting issue when the profile
+	// sets none.
+	defaultMeetingTitle = "SIG Auth triage meeting {date}"
+)
+
+// triageMeeting is the recurring triage meeting the meeting-issue command opens
+// an issue with the agenda for.
+type triageMeeting struct {
+	// Repo is the repository the meeting issues are opened in, in owner/name form.
+	Repo string `json:"repo"`
+	// Title is the title of the meeting issue, with {date} replaced by the
+	// meeting date. Empty means defaultMeetingTitle.
+	Title string `json:"title,omitempty"`
+	// First is the time of a past meeting, in YYYY-MM-DD HH:MM form in TimeZone,
+	// from which the following ones are computed.
+	First string `json:"first"`
+	// TimeZone is the IANA time zone of First, e.g. America/Los_Angeles, so the
+	// meeting keeps its local time across daylight saving changes. Empty means UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+	// IntervalDays is the number of days between meetings. Zero means 7.
+	IntervalDays int `json:"intervalDays,omitempty"`
+	// OpenHoursBefore is how long before the meeting the issue is opened. Zero
+	// means 24.
+	OpenHoursBefore int `json:"openHoursBefore,omitempty"`
+	// Rotation are the GitHub logins chairing the meetings in turn, starting
+	// with First.
+	Rotation []string `json:"rotation,omitempty"`
+}
+
+// next returns the first meeting at or after now and its index since First.
+func (m *triageMeeting) next(now time.Time) (time.Time, int, error) {
+	loc := time.UTC
+	if m.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(m.TimeZone); err != nil {
+			return time.Time{}, 0, fmt.Errorf("triage meeting: invalid timeZone: %w", err)
+		}
+	}
+	first, err := time.ParseInLocation(meetingTimeFormat, m.First, loc)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("triage meeting: invalid first meeting: %w", err)
+	}
+	interval := m.IntervalDays
+	if interval == 0 {
+		interval = 7
+	}
+
+	index := 0
+	if now.After(first) {
+		index = int(now.Sub(first).Hours() / 24 / float64(interval))
+	}
+	// Adding days in the location keeps the local time of the meeting.
+	meeting := first.AddDate(0, 0, index*interval)
+	for meeting.Before(now) {
+		index++
+		meeting = first.AddDate(0, 0, index*interval)
+	}
+	return meeting, index, nil
+}
+
+// chair returns the rotation member chairing the meeting with the index, empty
+// if there is no rotation.
+func (m *triageMeeting) chair(index int) string {
+	if len(m.Rotation) == 0 {
+		return ""
+	}
+	return m.Rotation[index%len(m.Rotation)]
+}
+
+// runMeetingIssue opens, or updates, the issue of the next triage meeting with
+// the agenda and the chair from the rotation, once the meeting is less than
+// openHoursBefore away. It is meant to run on an hourly schedule.
+func runMeetingIssue(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("meeting-issue", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	meeting := prof.TriageMeeting
+	if meeting == nil {
+		return fmt.Errorf("profile %q has no triageMeeting configured", common.profileName)
+	}
+
+	now := time.Now()
+	at, index, err := meeting.next(now)
+	if err != nil {
+		return err
+	}
+	before := meeting.OpenHoursBefore
+	if before == 0 {
+		before = 24
+	}
+	if at.Sub(now) > time.Duration(before)*time.Hour {
+		fmt.Printf("Next triage meeting on %s is more than %d hours away\n", at.Format(time.RFC1123), before)
+		return nil
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	title := meeting.Title
+	if title == "" {
+		title = defaultMeetingTitle
+	}
+	ref := issueRef{Repo: meeting.Repo, Title: strings.ReplaceAll(title, "{date}", at.Format(dateFormat))}
+	chair := meeting.chair(index)
+	var b strings.Builder
+	fmt.Fprintf(&b, "This issue is maintained by [sig-auth-tools](https://github.com/kubernetes-sigs/sig-auth-tools) "+
+		"and holds the agenda of the triage meeting on %s.\n\n", at.UTC().Format("Monday 2006-01-02 15:04 MST"))
+	if chair != "" {
+		fmt.Fprintf(&b, "Chair: @%s\n\n", chair)
+	}
+	b.WriteString(renderAgenda(prof, newSnapshot(project, items)))
+
+	issue, err := client.upsertIssue(ctx, ref, b.String())
+	if err != nil || chair == "" {
+		return err
+	}
+	owner, repo, err := ref.split()
+	if err != nil {
+		return err
+	}
+	_, _, err = client.Issues.AddAssignees(ctx, owner, repo, issue.GetNumber(), []string{chair})
+	return err
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTriageMeetingNext(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, tc := range []struct {
+		name    string
+		meeting triageMeeting
+		now     time.Time
+		want    time.Time
+		index   int
+	}{{
+		name:    "before the first meeting",
+		meeting: triageMeeting{First: "2023-06-05 16:00"},
+		now:     time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		want:    time.Date(2023, 6, 5, 16, 0, 0, 0, time.UTC),
+	}, {
+		name:    "at a meeting",
+		meeting: triageMeeting{First: "2023-06-05 16:00"},
+		now:     time.Date(2023, 6, 12, 16, 0, 0, 0, time.UTC),
+		want:    time.Date(2023, 6, 12, 16, 0, 0, 0, time.UTC),
+		index:   1,
+	}, {
+		name:    "just after a meeting",
+		meeting: triageMeeting{First: "2023-06-05 16:00"},
+		now:     time.Date(2023, 6, 12, 16, 1, 0, 0, time.UTC),
+		want:    time.Date(2023, 6, 19, 16, 0, 0, 0, time.UTC),
+		index:   2,
+	}, {
+		name:    "biweekly",
+		meeting: triageMeeting{First: "2023-06-05 16:00", IntervalDays: 14},
+		now:     time.Date(2023, 6, 13, 0, 0, 0, 0, time.UTC),
+		want:    time.Date(2023, 6, 19, 16, 0, 0, 0, time.UTC),
+		index:   1,
+	}, {
+		// The meeting keeps its local time across the end of daylight saving.
+		name:    "across a daylight saving change",
+		meeting: triageMeeting{First: "2023-10-30 09:00", TimeZone: "America/Los_Angeles"},
+		now:     time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC),
+		want:    time.Date(2023, 11, 6, 9, 0, 0, 0, la),
+		index:   1,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, index, err := tc.meeting.next(tc.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tc.want) || index != tc.index {
+				t.Errorf("next() = %s, %d, want %s, %d", got, index, tc.want, tc.index)
+			}
+		})
+	}
+}
+
+func TestTriageMeetingNextInvalid(t *testing.T) {
+	for _, meeting := range []triageMeeting{
+		{First: "2023-06-05"},
+		{First: "2023-06-05 16:00", TimeZone: "Mars/Olympus_Mons"},
+	} {
+		if _, _, err := meeting.next(time.Now()); err == nil {
+			t.Errorf("next() of %+v succeeded, want an error", meeting)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// pushgatewayJob is the job name metrics are grouped under in the Pushgateway.
+const pushgatewayJob = "sig-auth-tools"
+
+// runStats are the counters collected during a sync run.
+type runStats struct {
+	start time.Time
+	// phase is the step the run is in, reported when it fails.
+	phase string
+	// synced is the number of items matched by the sources.
+	synced int
+	// added are the URLs of the items newly added to the board.
+	added []string
+	// moved is the number of items whose status changed.
+	moved int
+	// boardItems is the number of active items on the board before the run.
+	boardItems int
+	// interrupted are the URLs of the items whose sync was cut short by
+	// cancellation, which may be on the board with an incomplete status.
+	interrupted []string
+}
+
+// pushMetrics pushes the metrics of the run to the Pushgateway, replacing the
+// metrics of the previous run of the same profile. runErr is the error the run
+// ended with, if any.
+func pushMetrics(pushgatewayURL, profileName string, client *ghClient, stats *runStats, runErr error) error {
+	// The run context may have expired, which is one of the failures worth pushing.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	success := 1
+	if runErr != nil {
+		success = 0
+	}
+
+	var b strings.Builder
+	writeMetric(&b, "sig_auth_tools_last_run_timestamp_seconds", "gauge", "Time the last run finished.", float64(time.Now().Unix()))
+	writeMetric(&b, "sig_auth_tools_last_run_success", "gauge", "Whether the last run succeeded.", float64(success))
+	writeMetric(&b, "sig_auth_tools_run_duration_seconds", "gauge", "Duration of the last run.", time.Since(stats.start).Seconds())
+	writeMetric(&b, "sig_auth_tools_items_synced", "gauge", "Items matched by the sources in the last run.", float64(stats.synced))
+	writeMetric(&b, "sig_auth_tools_items_added", "gauge", "Items added to the board in the last run.", float64(len(stats.added)))
+	writeMetric(&b, "sig_auth_tools_status_changes", "gauge", "Status changes made in the last run.", float64(stats.moved))
+	writeMetric(&b, "sig_auth_tools_board_items", "gauge", "Active items on the board before the last run.", float64(stats.boardItems))
+	writeMetric(&b, "sig_auth_tools_items_interrupted", "gauge", "Items whose sync was cut short by cancellation in the last run.", float64(len(stats.interrupted)))
+	writeMetric(&b, "sig_auth_tools_mutations", "gauge", "Board mutations made in the last run.", float64(client.mutations))
+
+	limits, _, err := client.RateLimits(ctx)
+	if err != nil {
+		fmt.Printf("failed to get rate limits: %v\n", err)
+	} else {
+		fmt.Fprintf(&b, "# HELP sig_auth_tools_rate_limit_remaining Remaining GitHub API rate limit after the last run.\n")
+		fmt.Fprintf(&b, "# TYPE sig_auth_tools_rate_limit_remaining gauge\n")
+		fmt.Fprintf(&b, "sig_auth_tools_rate_limit_remaining{api=\"core\"} %d\n", limits.GetCore().Remaining)
+		fmt.Fprintf(&b, "sig_auth_tools_rate_limit_remaining{api=\"graphql\"} %d\n", limits.GetGraphQL().Remaining)
+	}
+
+	target := fmt.Sprintf("%s/metrics/job/%s/profile/%s", strings.TrimSuffix(pushgatewayURL, "/"), pushgatewayJob, url.PathEscape(profileName))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewBufferString(b.String()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pushing metrics to %s failed with status %s", target, resp.Status)
+	}
+	return nil
+}
+
+func writeMetric(b *strings.Builder, name, typ, help string, value float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n%s %s\n", name, help, name, typ, name, formatNumber(value))
+}
@@ -0,0 +1,191 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// releaseMilestoneRE matches the milestones of Kubernetes minor releases, e.g. "v1.34".
+var releaseMilestoneRE = regexp.MustCompile(`^v(\d+)\.(\d+)$`)
+
+func (c *ghClient) listMilestones(ctx context.Context, owner, repo, state string) ([]*github.Milestone, error) {
+	var allMilestones []*github.Milestone
+	opts := &github.MilestoneListOptions{
+		State:       state,
+		ListOptions: github.ListOptions{PerPage: perPage},
+	}
+
+	for {
+		milestones, resp, err := c.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		allMilestones = append(allMilestones, milestones...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allMilestones, nil
+}
+
+// findMilestone returns the milestone of the repository with the given title,
+// or nil if there is none.
+func (c *ghClient) findMilestone(ctx context.Context, owner, repo, title string) (*github.Milestone, error) {
+	milestones, err := c.listMilestones(ctx, owner, repo, "all")
+	if err != nil {
+		return nil, err
+	}
+	for _, milestone := range milestones {
+		if milestone.GetTitle() == title {
+			return milestone, nil
+		}
+	}
+	return nil, nil
+}
+
+// currentMilestone returns the title of the release currently in progress,
+// i.e. the oldest open release milestone of kubernetes/kubernetes.
+func (c *ghClient) currentMilestone(ctx context.Context) (string, error) {
+	milestones, err := c.listMilestones(ctx, orgName, "kubernetes", "open")
+	if err != nil {
+		return "", err
+	}
+
+	var current string
+	var currentMinor int
+	for _, milestone := range milestones {
+		m := releaseMilestoneRE.FindStringSubmatch(milestone.GetTitle())
+		if m == nil {
+			continue
+		}
+		// The major version has been 1 for the whole life of the project.
+		minor, _ := strconv.Atoi(m[2])
+		if current == "" || minor < currentMinor {
+			current, currentMinor = milestone.GetTitle(), minor
+		}
+	}
+	if current == "" {
+		return "", fmt.Errorf("no open release milestone in %s/kubernetes", orgName)
+	}
+	return current, nil
+}
+
+// milestoneSpec is a milestone every subproject repository must have.
+type milestoneSpec struct {
+	Title string `json:"title"`
+	// DueOn is the due date in YYYY-MM-DD form. Empty means no due date.
+	DueOn       string `json:"dueOn,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// dueOn returns the due date, or nil if there is none. GitHub only keeps the
+// date of due times.
+func (m milestoneSpec) dueOn() (*time.Time, error) {
+	if m.DueOn == "" {
+		return nil, nil
+	}
+	due, err := time.Parse(dateFormat, m.DueOn)
+	if err != nil {
+		return nil, fmt.Errorf("milestone %q: invalid due date: %w", m.Title, err)
+	}
+	return &due, nil
+}
+
+// runSyncMilestones creates the configured milestones in every subproject
+// repository and aligns the due date and description of existing ones.
+func runSyncMilestones(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("sync-milestones", flag.ExitOnError)
+	common.register(fs)
+	dryRun := fs.Bool("dry-run", false, "only print the milestones that would be created or updated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Milestones) == 0 {
+		return fmt.Errorf("no milestones configured")
+	}
+
+	client := newClient(ctx, tokens)
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+		existing, err := client.listMilestones(ctx, owner, name, "all")
+		if err != nil {
+			return err
+		}
+		byTitle := map[string]*github.Milestone{}
+		for _, m := range existing {
+			byTitle[m.GetTitle()] = m
+		}
+
+		for _, spec := range cfg.Milestones {
+			due, err := spec.dueOn()
+			if err != nil {
+				return err
+			}
+			milestone := &github.Milestone{
+				Title:       github.String(spec.Title),
+				Description: github.String(spec.Description),
+				DueOn:       due,
+			}
+
+			current, ok := byTitle[spec.Title]
+			switch {
+			case !ok:
+				fmt.Printf("creating milestone %q in %s\n", spec.Title, repo.GetFullName())
+				if !*dryRun {
+					_, _, err = client.Issues.CreateMilestone(ctx, owner, name, milestone)
+				}
+			case formatDueOn(current.DueOn) != spec.DueOn || current.GetDescription() != spec.Description:
+				fmt.Printf("updating milestone %q in %s\n", spec.Title, repo.GetFullName())
+				if !*dryRun {
+					_, _, err = client.Issues.EditMilestone(ctx, owner, name, current.GetNumber(), milestone)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatDueOn(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	return due.UTC().Format(dateFormat)
+}
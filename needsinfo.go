@@ -0,0 +1,151 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// statusNeedsInformation is the status of bug reports missing details needed
+// to triage them.
+const statusNeedsInformation = "Needs Information"
+
+// defaultNeedsInformationComment is the comment posted on bug reports moved to
+// statusNeedsInformation when the policy sets none.
+const defaultNeedsInformationComment = `/triage needs-information
+
+Thanks for the report! To help SIG Auth triage it, please edit the description to add the following: {missing}.`
+
+// needsInformationPolicy flags incoming bug reports that lack the details
+// asked for by the bug report template.
+type needsInformationPolicy struct {
+	// Comment is posted when a bug report is moved to the Needs Information
+	// status, with {missing} replaced by the missing details. Empty means
+	// the default comment, "-" means no comment.
+	Comment string `json:"comment,omitempty"`
+}
+
+var (
+	// headingRE matches the section headings of the issue templates.
+	headingRE = regexp.MustCompile(`(?m)^#{2,4}\s+(.+?)\s*$`)
+	// htmlCommentRE matches the instructions left in the templates.
+	htmlCommentRE = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// versionRE matches a Kubernetes version anywhere in the body.
+	versionRE = regexp.MustCompile(`v?1\.\d{1,2}(\.\d+)?`)
+)
+
+// kubernetesVersionKeyword is the keyword of the heading of the Kubernetes
+// version section, distinct from the OS and container runtime versions.
+const kubernetesVersionKeyword = "kubernetes version"
+
+// noResponse is the content issue forms render for fields left empty.
+const noResponse = "_No response_"
+
+// bugReportSections are the sections of the kubernetes/kubernetes bug report
+// template whose absence means the report cannot be triaged, by the keyword
+// its heading contains and the description of what is missing.
+var bugReportSections = []struct {
+	keyword string
+	missing string
+}{
+	{"what happened", "what happened"},
+	{"expect", "what you expected to happen"},
+	{"reproduce", "steps to reproduce the problem"},
+}
+
+// missingInformation returns the details the bug report lacks, empty if it is
+// complete or not a bug report.
+func missingInformation(issue *github.Issue) []string {
+	if issue.IsPullRequest() || !hasLabel(issue, "kind/bug") {
+		return nil
+	}
+	body := htmlCommentRE.ReplaceAllString(issue.GetBody(), "")
+	sections := templateSections(body)
+
+	var missing []string
+	for _, section := range bugReportSections {
+		if content, ok := findSection(sections, section.keyword); ok && content == "" {
+			missing = append(missing, section.missing)
+		}
+	}
+	if content, ok := findSection(sections, kubernetesVersionKeyword); (ok && !versionRE.MatchString(content)) || (!ok && !versionRE.MatchString(body)) {
+		missing = append(missing, "the Kubernetes version")
+	}
+	return missing
+}
+
+// templateSection is a section of an issue filled from a template.
+type templateSection struct {
+	// heading is lower-cased.
+	heading string
+	// content is trimmed, and empty for fields left empty in issue forms.
+	content string
+}
+
+// templateSections returns the sections of the body in order.
+func templateSections(body string) []templateSection {
+	var sections []templateSection
+	matches := headingRE.FindAllStringSubmatchIndex(body, -1)
+	for i, m := range matches {
+		end := len(body)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		content := strings.TrimSpace(body[m[1]:end])
+		if content == noResponse {
+			content = ""
+		}
+		sections = append(sections, templateSection{heading: strings.ToLower(body[m[2]:m[3]]), content: content})
+	}
+	return sections
+}
+
+// findSection returns the content of the first section whose heading contains
+// the keyword.
+func findSection(sections []templateSection, keyword string) (string, bool) {
+	for _, section := range sections {
+		if strings.Contains(section.heading, keyword) {
+			return section.content, true
+		}
+	}
+	return "", false
+}
+
+// askForInformation comments on the bug report asking for the missing details.
+func (s *syncer) askForInformation(ctx context.Context, issue *github.Issue) error {
+	comment := s.profile.NeedsInformation.Comment
+	switch comment {
+	case "-":
+		return nil
+	case "":
+		comment = defaultNeedsInformationComment
+	}
+	body := strings.ReplaceAll(comment, "{missing}", strings.Join(missingInformation(issue), ", "))
+
+	owner, repo := issueRepo(issue)
+	fmt.Printf("asking for more information on %s/%s#%d\n", owner, repo, *issue.Number)
+	_, _, err := s.client.Issues.CreateComment(ctx, owner, repo, *issue.Number, &github.IssueComment{
+		Body: github.String(body),
+	})
+	s.client.changelog.record(auditEntry{Action: changelogComment, Content: issue.GetHTMLURL(), After: body}, err)
+	return err
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// bugReport renders a kubernetes/kubernetes bug report form with the given
+// answers, "_No response_" standing for the fields left empty.
+func bugReport(happened, expected, reproduce, kubernetesVersion, osVersion, runtime string) string {
+	return strings.Join([]string{
+		"### What happened?", happened,
+		"### What did you expect to happen?", expected,
+		"### How can we reproduce it (as minimally and precisely as possible)?", reproduce,
+		"### Anything else we need to know?", noResponse,
+		"### Kubernetes version", kubernetesVersion,
+		"### Cloud provider", noResponse,
+		"### OS version", osVersion,
+		"### Install tools", noResponse,
+		"### Container runtime (CRI) and version (if applicable)", runtime,
+		"### Related plugins (CNI, CSI, ...) and versions (if applicable)", noResponse,
+	}, "\n\n")
+}
+
+const kubectlVersion = "<details>\n\n```console\n$ kubectl version\nClient Version: v1.27.2\nServer Version: v1.27.1\n```\n\n</details>"
+
+func TestMissingInformation(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		body    string
+		labels  []string
+		pr      bool
+		missing []string
+	}{{
+		name:   "complete",
+		body:   bugReport("The webhook authenticator hangs.", "It times out.", "Configure a slow webhook.", kubectlVersion, "Ubuntu 22.04", "containerd 1.7.1"),
+		labels: []string{"kind/bug"},
+	}, {
+		// The other version sections must not stand in for the Kubernetes version.
+		name:    "only other versions",
+		body:    bugReport("The webhook authenticator hangs.", "It times out.", "Configure a slow webhook.", noResponse, "Ubuntu 22.04 (kernel 5.15)", "containerd 1.7.1"),
+		labels:  []string{"kind/bug"},
+		missing: []string{"the Kubernetes version"},
+	}, {
+		name:    "empty fields",
+		body:    bugReport("The webhook authenticator hangs.", noResponse, noResponse, kubectlVersion, noResponse, noResponse),
+		labels:  []string{"kind/bug"},
+		missing: []string{"what you expected to happen", "steps to reproduce the problem"},
+	}, {
+		name:    "version left as template instructions",
+		body:    bugReport("a", "b", "c", "<details>\n\n<!-- paste the output of kubectl version -->\n\n</details>", noResponse, noResponse),
+		labels:  []string{"kind/bug"},
+		missing: []string{"the Kubernetes version"},
+	}, {
+		name:   "free-form report with a version",
+		body:   "The apiserver on v1.26.3 rejects my token.",
+		labels: []string{"kind/bug"},
+	}, {
+		name:    "free-form report without a version",
+		body:    "The apiserver rejects my token.",
+		labels:  []string{"kind/bug"},
+		missing: []string{"the Kubernetes version"},
+	}, {
+		name: "not a bug",
+		body: "Support OIDC discovery.",
+	}, {
+		name:   "pull request",
+		body:   "Fixes the token cache.",
+		labels: []string{"kind/bug"},
+		pr:     true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := &github.Issue{Body: github.String(tc.body)}
+			for _, label := range tc.labels {
+				issue.Labels = append(issue.Labels, &github.Label{Name: github.String(label)})
+			}
+			if tc.pr {
+				issue.PullRequestLinks = &github.PullRequestLinks{}
+			}
+			// Run repeatedly, the result must not depend on any ordering.
+			for i := 0; i < 20; i++ {
+				if got := missingInformation(issue); !reflect.DeepEqual(got, tc.missing) {
+					t.Fatalf("missingInformation() = %q, want %q", got, tc.missing)
+				}
+			}
+		})
+	}
+}
+
+func TestFindSection(t *testing.T) {
+	sections := templateSections(bugReport("a", "b", "c", kubectlVersion, "Ubuntu 22.04", "containerd 1.7.1"))
+	for _, tc := range []struct {
+		keyword string
+		content string
+		found   bool
+	}{
+		{kubernetesVersionKeyword, kubectlVersion, true},
+		{"os version", "Ubuntu 22.04", true},
+		// The first matching heading wins.
+		{"version", kubectlVersion, true},
+		{"cloud provider", "", true},
+		{"environment", "", false},
+	} {
+		content, found := findSection(sections, tc.keyword)
+		if content != tc.content || found != tc.found {
+			t.Errorf("findSection(%q) = %q, %v, want %q, %v", tc.keyword, content, found, tc.content, tc.found)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// notesFieldName is the name of the text field seeded with triage hints when
+// an item is imported.
+const notesFieldName = "Notes"
+
+// componentRE matches the Kubernetes components SIG Auth items commonly
+// mention.
+var componentRE = regexp.MustCompile(`\b(kube-apiserver|kube-controller-manager|kube-scheduler|kube-proxy|kubelet|kubectl|kubeadm|client-go|etcd)\b`)
+
+// syncNotes seeds the Notes field of newly imported items with the reported
+// Kubernetes version, the components mentioned and whether a KEP or PR is
+// linked, saving triagers a click into every item. The field is then left to
+// the triagers.
+func (s *syncer) syncNotes(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	if !item.added || item.values[notesFieldName] != "" {
+		return nil
+	}
+
+	body := htmlCommentRE.ReplaceAllString(issue.GetBody(), "")
+	var notes []string
+	version := body
+	if content, ok := findSection(templateSections(body), kubernetesVersionKeyword); ok {
+		version = content
+	}
+	if v := versionRE.FindString(version); v != "" && !issue.IsPullRequest() {
+		notes = append(notes, "Version: "+v)
+	}
+
+	var components []string
+	seen := map[string]bool{}
+	for _, c := range componentRE.FindAllString(issue.GetTitle()+"\n"+body, -1) {
+		if !seen[c] {
+			seen[c] = true
+			components = append(components, c)
+		}
+	}
+	if len(components) > 0 {
+		notes = append(notes, "Components: "+strings.Join(components, ", "))
+	}
+
+	if keps := referencedKEPs(issue); len(keps) > 0 {
+		notes = append(notes, "KEP: "+strings.Join(keps, ", "))
+	}
+	if !issue.IsPullRequest() {
+		linked, err := s.client.hasOpenLinkedPullRequest(ctx, issue.GetNodeID())
+		if err != nil {
+			return err
+		}
+		if linked {
+			notes = append(notes, "PR: linked")
+		} else {
+			notes = append(notes, "PR: none")
+		}
+	}
+
+	if len(notes) == 0 {
+		return nil
+	}
+	return s.client.setTextField(ctx, s.project, item, notesFieldName, strings.Join(notes, "; "))
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// parentFieldName is the name of the text field holding the umbrella issue an
+// item is part of, in owner/repo#number form.
+const parentFieldName = "Parent"
+
+var (
+	// taskRE matches the items of a task list.
+	taskRE = regexp.MustCompile(`(?m)^\s*[-*]\s+\[[ xX]\]\s+(.*)$`)
+	// taskReferenceRE matches the issue references of a task: #123,
+	// owner/repo#123 and issue or PR URLs.
+	taskReferenceRE = regexp.MustCompile(`(?:^|[\s(])(?:([\w.-]+)/([\w.-]+))?#(\d+)\b|github\.com/([\w.-]+)/([\w.-]+)/(?:issues|pull)/(\d+)`)
+)
+
+// issueReference returns the owner/repo#number reference of the issue.
+func issueReference(issue *github.Issue) string {
+	owner, repo := issueRepo(issue)
+	return fmt.Sprintf("%s/%s#%d", owner, repo, issue.GetNumber())
+}
+
+// taskListChildren returns the references of the issues listed in the task
+// lists of the issue body, in owner/repo#number form.
+func taskListChildren(issue *github.Issue) []string {
+	owner, repo := issueRepo(issue)
+	var children []string
+	for _, task := range taskRE.FindAllStringSubmatch(issue.GetBody(), -1) {
+		for _, m := range taskReferenceRE.FindAllStringSubmatch(task[1], -1) {
+			children = append(children, resolveReference(m, owner, repo))
+		}
+	}
+	return children
+}
+
+// resolveReference returns the owner/repo#number form of a taskReferenceRE
+// match. Relative references are to the given repository.
+func resolveReference(m []string, owner, repo string) string {
+	refOwner, refRepo, number := m[1], m[2], m[3]
+	if number == "" {
+		refOwner, refRepo, number = m[4], m[5], m[6]
+	}
+	if refOwner == "" {
+		refOwner, refRepo = owner, repo
+	}
+	return fmt.Sprintf("%s/%s#%s", refOwner, refRepo, number)
+}
+
+// recordHierarchy remembers the synced item and the children listed by its task
+// lists, for syncParents. The task lists of closed umbrella issues are ignored,
+// their children no longer have a parent. It is a no-op outside of a full sync.
+func (s *syncer) recordHierarchy(item *projectItem, issue *github.Issue) {
+	if s.itemsByRef == nil {
+		return
+	}
+	ref := issueReference(issue)
+	s.itemsByRef[ref] = item
+	if issue.IsPullRequest() || issue.GetState() == "closed" {
+		return
+	}
+	for _, child := range taskListChildren(issue) {
+		if child != ref {
+			s.parents[child] = ref
+		}
+	}
+}
+
+// syncParents sets the Parent field of the synced items listed in the task list
+// of an open synced umbrella issue, and clears it on the synced items whose
+// umbrella issue no longer lists them, see staleParent. It runs once all items
+// are synced, as children may be synced before their umbrella issue.
+func (s *syncer) syncParents(ctx context.Context) error {
+	for ref, item := range s.itemsByRef {
+		parent, ok := s.parents[ref]
+		switch {
+		case ok:
+			if err := s.client.setTextField(ctx, s.project, item, parentFieldName, parent); err != nil {
+				return err
+			}
+		case s.staleParent(item):
+			if err := s.client.clearField(ctx, s.project, item, parentFieldName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// staleParent reports whether the Parent field of the item, which no synced
+// umbrella issue lists, names an umbrella issue synced by the run. Umbrella
+// issues outside the run, e.g. filtered out by --repos, are not known to no
+// longer list the item, so their children keep their Parent.
+func (s *syncer) staleParent(item *projectItem) bool {
+	parent := item.values[parentFieldName]
+	if parent == "" {
+		return false
+	}
+	_, synced := s.itemsByRef[parent]
+	return synced
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+)
+
+func TestTaskListChildren(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		body     string
+		children []string
+	}{{
+		name: "no task list",
+		body: "Tracks the removal of #100.\n\n- #101",
+	}, {
+		name: "relative and qualified references",
+		body: "- [ ] #101\n- [x] kubernetes-sigs/secrets-store-csi-driver#7\n* [X] Storage version (#102)",
+		children: []string{
+			"kubernetes/kubernetes#101",
+			"kubernetes-sigs/secrets-store-csi-driver#7",
+			"kubernetes/kubernetes#102",
+		},
+	}, {
+		name: "URLs",
+		body: "  - [ ] https://github.com/kubernetes/enhancements/issues/3299\n  - [ ] https://github.com/kubernetes/kubernetes/pull/115000",
+		children: []string{
+			"kubernetes/enhancements#3299",
+			"kubernetes/kubernetes#115000",
+		},
+	}, {
+		name:     "several references in a task",
+		body:     "- [ ] Alpha: #103, #104",
+		children: []string{"kubernetes/kubernetes#103", "kubernetes/kubernetes#104"},
+	}, {
+		name: "task without reference",
+		body: "- [ ] Write the KEP",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := &github.Issue{
+				RepositoryURL: github.String("https://api.github.com/repos/kubernetes/kubernetes"),
+				Body:          github.String(tc.body),
+			}
+			if got := taskListChildren(issue); !reflect.DeepEqual(got, tc.children) {
+				t.Errorf("taskListChildren() = %q, want %q", got, tc.children)
+			}
+		})
+	}
+}
+
+func TestStaleParent(t *testing.T) {
+	umbrella := &projectItem{}
+	s := &syncer{
+		itemsByRef: map[string]*projectItem{"kubernetes/kubernetes#100": umbrella},
+	}
+	for _, tc := range []struct {
+		name   string
+		values map[string]string
+		want   bool
+	}{{
+		name:   "no parent",
+		values: map[string]string{},
+	}, {
+		name:   "umbrella synced by the run",
+		values: map[string]string{parentFieldName: "kubernetes/kubernetes#100"},
+		want:   true,
+	}, {
+		// e.g. sync --repos kubernetes/kubernetes for a child of an umbrella
+		// issue in kubernetes/enhancements.
+		name:   "umbrella outside a filtered run",
+		values: map[string]string{parentFieldName: "kubernetes/enhancements#3299"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := s.staleParent(&projectItem{values: tc.values}); got != tc.want {
+				t.Errorf("staleParent() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// mutationPlan collects the board mutations of a run instead of making them.
+// Written to a file by the plan command, it is reviewed before being executed
+// by the apply command.
+type mutationPlan struct {
+	Time    time.Time    `json:"time,omitempty"`
+	Profile string       `json:"profile,omitempty"`
+	Project string       `json:"project,omitempty"`
+	Entries []auditEntry `json:"entries"`
+}
+
+func (p *mutationPlan) add(entry auditEntry) {
+	p.Entries = append(p.Entries, entry)
+}
+
+// describe returns a human readable description of the mutation.
+func (e auditEntry) describe() string {
+	switch e.Action {
+	case auditAdd:
+		return fmt.Sprintf("add %s", e.Content)
+	case auditUpdate:
+		return fmt.Sprintf("set %s of %s from %q to %q", e.Field, e.Content, e.Before, e.After)
+	case auditClear:
+		return fmt.Sprintf("clear %s of %s, was %q", e.Field, e.Content, e.Before)
+	}
+	return fmt.Sprintf("%s %s", e.Action, e.Content)
+}
+
+// applyEntry makes the planned mutation of the item. The item value the entry
+// was planned against is restored first, since planning updates it locally, so
+// that values changed since are not overwritten.
+func (c *ghClient) applyEntry(ctx context.Context, p *project, item *projectItem, entry auditEntry) error {
+	switch entry.Action {
+	case auditUpdate, auditClear:
+		if item.values == nil {
+			item.values = map[string]string{}
+		}
+		item.values[entry.Field] = entry.Before
+		if entry.Action == auditClear {
+			return c.clearField(ctx, p, item, entry.Field)
+		}
+		return c.setFieldValue(ctx, p, item, entry.Field, entry.After)
+	case auditArchive:
+		return c.archiveProjectV2Item(ctx, p, item)
+	case auditDelete:
+		return c.deleteProjectV2Item(ctx, p, item)
+	}
+	return fmt.Errorf("cannot apply %s of %s", entry.Action, entry.Content)
+}
+
+// runPlan writes the mutations reconciling the drift found by validate to a
+// plan file, for a second person to review before it is applied.
+func runPlan(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	common.register(fs)
+	out := fs.String("out", "plan.json", "file to write the plan to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := newValidateSyncer(ctx, tokens, common)
+	if err != nil {
+		return err
+	}
+	drifts, err := s.findDrift(ctx)
+	if err != nil {
+		return err
+	}
+
+	plan := &mutationPlan{Time: time.Now().UTC(), Profile: common.profileName, Project: s.project.Title}
+	for _, d := range drifts {
+		plan.add(d.Entry)
+		fmt.Println(d.Entry.describe())
+	}
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %d planned changes to %s\n", len(plan.Entries), *out)
+	return nil
+}
+
+// runApply executes a plan written by the plan command. Changes to items whose
+// field changed since the plan was written are skipped.
+func runApply(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: apply [flags] <plan file>")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var plan mutationPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return fmt.Errorf("parsing plan %q: %w", fs.Arg(0), err)
+	}
+
+	s, err := newValidateSyncer(ctx, tokens, common)
+	if err != nil {
+		return err
+	}
+	if plan.Project != s.project.Title {
+		return fmt.Errorf("plan %q is for project %q, not %q", fs.Arg(0), plan.Project, s.project.Title)
+	}
+	scope, err := s.client.loadBoardScope(ctx, s.profile)
+	if err != nil {
+		return err
+	}
+	items, err := s.client.listProjectItems(ctx, s.project)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*projectItem, len(items))
+	byURL := make(map[string]*projectItem, len(items))
+	for _, item := range items {
+		byID[fmt.Sprint(item.ID)] = item
+		byURL[item.URL] = item
+	}
+
+	for _, entry := range plan.Entries {
+		if entry.Action == auditAdd {
+			if byURL[entry.Content] != nil {
+				continue
+			}
+			if err := s.applyAdd(ctx, scope, entry); err != nil {
+				return err
+			}
+			continue
+		}
+		item := byID[entry.ItemID]
+		if item == nil {
+			fmt.Printf("skipping %s, no longer on the board\n", entry.describe())
+			continue
+		}
+		fmt.Println(entry.describe())
+		if err := s.client.applyEntry(ctx, s.project, item, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyAdd syncs the planned item into the project, if a source still imports it.
+func (s *syncer) applyAdd(ctx context.Context, scope *boardScope, entry auditEntry) error {
+	m := taskReferenceRE.FindStringSubmatch(entry.Content)
+	if m == nil || m[6] == "" {
+		return fmt.Errorf("invalid item URL %q", entry.Content)
+	}
+	number, err := strconv.Atoi(m[6])
+	if err != nil {
+		return err
+	}
+	issue, _, err := s.client.Issues.Get(ctx, m[4], m[5], number)
+	if err != nil {
+		return err
+	}
+
+	var labels []string
+	for _, label := range issue.Labels {
+		labels = append(labels, label.GetName())
+	}
+	src, ok := scope.sourceOf(m[4]+"/"+m[5], labels)
+	if !ok || issue.GetState() != "open" || !s.filter.forSource(src).matches(issue) {
+		fmt.Printf("skipping %s, no longer imported\n", entry.describe())
+		return nil
+	}
+	fmt.Println(entry.describe())
+	return s.addAndUpdateProjectItem(ctx, src, issue)
+}
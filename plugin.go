@@ -0,0 +1,246 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// pluginActions are the issue and PR event actions that can change whether and
+// how an item is on the board.
+var pluginActions = map[string]bool{
+	"opened":     true,
+	"reopened":   true,
+	"labeled":    true,
+	"unlabeled":  true,
+	"milestoned": true,
+}
+
+const (
+	// maxPayloadSize is the size from which event payloads are rejected,
+	// GitHub caps them at 25 MB.
+	maxPayloadSize = 25 << 20
+	// deliveryRetention is how long delivery IDs are remembered to reject
+	// replayed events.
+	deliveryRetention = 24 * time.Hour
+)
+
+// plugin is a Prow external plugin syncing the items of the events Prow's hook
+// forwards to it.
+// xref: https://docs.prow.k8s.io/docs/components/plugins/#external-plugins
+type plugin struct {
+	secret []byte
+	// mu serializes syncs, since the syncer and its client are not safe for
+	// concurrent use.
+	mu sync.Mutex
+	s  *syncer
+
+	// deliveries are the IDs of the events handled in the last
+	// deliveryRetention, with the time they were received.
+	deliveriesMu sync.Mutex
+	deliveries   map[string]time.Time
+}
+
+func runPlugin(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("plugin", flag.ExitOnError)
+	common.register(fs)
+	addr := fs.String("addr", ":8888", "address to listen on")
+	hmacSecretFile := fs.String("hmac-secret-file", "", "file holding the HMAC secret Prow's hook signs events with")
+	includeBots := fs.Bool("include-bots", false, "also sync items authored by the bot accounts in the config")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *hmacSecretFile == "" {
+		return fmt.Errorf("--hmac-secret-file is required")
+	}
+	secret, err := os.ReadFile(*hmacSecretFile)
+	if err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	filter := itemFilter{pullRequestsOnly: prof.PullRequestsOnly, exemptLabels: cfg.ExemptLabels}
+	if !*includeBots {
+		filter.excludedAuthors = stringSet(cfg.BotAuthors)
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+
+	p := &plugin{
+		secret:     bytes.TrimSpace(secret),
+		deliveries: map[string]time.Time{},
+		s: &syncer{
+			stats:      &runStats{start: time.Now()},
+			client:     client,
+			project:    project,
+			profile:    prof,
+			filter:     filter,
+			botAuthors: stringSet(cfg.BotAuthors),
+			schedule:   cfg.ReleaseSchedule,
+		},
+	}
+	fmt.Printf("listening on %s\n", *addr)
+	return http.ListenAndServe(*addr, p)
+}
+
+func (p *plugin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The endpoint is internet-facing: only events of a bounded size signed
+	// with SHA-256 are accepted, and each delivery only once.
+	signature := r.Header.Get(github.SHA256SignatureHeader)
+	if signature == "" {
+		http.Error(w, "missing "+github.SHA256SignatureHeader+" header", http.StatusUnauthorized)
+		return
+	}
+	// ValidatePayloadFromBody also accepts SHA-1 signatures.
+	if !strings.HasPrefix(signature, "sha256=") {
+		http.Error(w, "the "+github.SHA256SignatureHeader+" header must be a sha256= signature", http.StatusUnauthorized)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxPayloadSize)
+	payload, err := github.ValidatePayloadFromBody(r.Header.Get("Content-Type"), r.Body, signature, p.secret)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	deliveryID := github.DeliveryID(r)
+	if deliveryID == "" {
+		http.Error(w, "missing "+github.DeliveryIDHeader+" header", http.StatusBadRequest)
+		return
+	}
+	event, err := github.ParseWebHook(github.WebHookType(r), payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var repo *github.Repository
+	var number int
+	switch e := event.(type) {
+	case *github.IssuesEvent:
+		if !pluginActions[e.GetAction()] {
+			return
+		}
+		repo, number = e.GetRepo(), e.GetIssue().GetNumber()
+	case *github.PullRequestEvent:
+		if !pluginActions[e.GetAction()] {
+			return
+		}
+		repo, number = e.GetRepo(), e.GetNumber()
+	default:
+		return
+	}
+
+	if !p.firstDelivery(deliveryID, time.Now()) {
+		http.Error(w, "delivery "+deliveryID+" was already handled", http.StatusConflict)
+		return
+	}
+	// Hook does not wait for external plugins, so the event is handled in the
+	// background.
+	go p.handle(deliveryID, repo, number)
+}
+
+// handle syncs the issue or PR of the delivery. The delivery is forgotten when
+// the sync fails, so that GitHub's redelivery of the event is handled.
+func (p *plugin) handle(deliveryID string, repo *github.Repository, number int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := p.s.syncIssue(ctx, repo, number); err != nil {
+		fmt.Printf("failed to sync %s#%d: %v\n", repo.GetFullName(), number, err)
+		p.forgetDelivery(deliveryID)
+	}
+}
+
+// syncIssue adds the issue or PR to the project if it belongs to one of the
+// profile's sources and passes the filter.
+func (s *syncer) syncIssue(ctx context.Context, repo *github.Repository, number int) error {
+	owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+	// The labels in the event may be stale by the time it is handled.
+	issue, _, err := s.client.Issues.Get(ctx, owner, name, number)
+	if err != nil {
+		return err
+	}
+	if issue.GetState() != "open" {
+		return nil
+	}
+
+	for _, src := range s.profile.Sources {
+		if (len(src.Repos) == 0 && src.Org != owner) || !src.includesRepo(repo) || !s.filter.forSource(src).matches(issue) {
+			continue
+		}
+		matches := true
+		for _, label := range src.Labels {
+			matches = matches && hasLabel(issue, label)
+		}
+		if !matches {
+			continue
+		}
+		fmt.Printf("adding [%d] %s to project\n", number, issue.GetTitle())
+		return s.addAndUpdateProjectItem(ctx, src, issue)
+	}
+	return nil
+}
+
+// firstDelivery records the delivery and reports whether it was not seen in
+// the last deliveryRetention. Deliveries are recorded when their sync starts,
+// so that concurrent redeliveries are rejected, and forgotten if it fails.
+func (p *plugin) firstDelivery(id string, now time.Time) bool {
+	p.deliveriesMu.Lock()
+	defer p.deliveriesMu.Unlock()
+	for seen, at := range p.deliveries {
+		if now.Sub(at) > deliveryRetention {
+			delete(p.deliveries, seen)
+		}
+	}
+	if _, ok := p.deliveries[id]; ok {
+		return false
+	}
+	p.deliveries[id] = now
+	return true
+}
+
+// forgetDelivery removes the delivery, whose sync failed.
+func (p *plugin) forgetDelivery(id string) {
+	p.deliveriesMu.Lock()
+	defer p.deliveriesMu.Unlock()
+	delete(p.deliveries, id)
+}
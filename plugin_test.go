@@ -0,0 +1,111 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const testSecret = "hmac-secret"
+
+func sign(h func() hash.Hash, prefix, payload string) string {
+	mac := hmac.New(h, []byte(testSecret))
+	mac.Write([]byte(payload))
+	return prefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestPluginSignature(t *testing.T) {
+	// Closed issues are not synced, so the accepted events are not handled.
+	const payload = `{"action": "closed", "issue": {"number": 1}, "repo": {"full_name": "kubernetes/kubernetes"}}`
+	for _, tc := range []struct {
+		name      string
+		signature string
+		delivery  string
+		want      int
+	}{
+		{"valid", sign(sha256.New, "sha256=", payload), "1", http.StatusOK},
+		{"missing", "", "2", http.StatusUnauthorized},
+		{"SHA-1", sign(sha1.New, "sha1=", payload), "3", http.StatusUnauthorized},
+		{"other secret", "sha256=" + strings.Repeat("0", 64), "4", http.StatusUnauthorized},
+		{"missing delivery ID", sign(sha256.New, "sha256=", payload), "", http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &plugin{secret: []byte(testSecret), deliveries: map[string]time.Time{}}
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(github.EventTypeHeader, "issues")
+			if tc.signature != "" {
+				req.Header.Set(github.SHA256SignatureHeader, tc.signature)
+			}
+			if tc.delivery != "" {
+				req.Header.Set(github.DeliveryIDHeader, tc.delivery)
+			}
+			w := httptest.NewRecorder()
+			p.ServeHTTP(w, req)
+			if w.Code != tc.want {
+				t.Errorf("ServeHTTP() status = %d, want %d: %s", w.Code, tc.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestFirstDelivery(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	p := &plugin{deliveries: map[string]time.Time{
+		"recent":  now.Add(-time.Hour),
+		"expired": now.Add(-deliveryRetention - time.Minute),
+	}}
+	for _, tc := range []struct {
+		id   string
+		want bool
+	}{
+		{"new", true},
+		{"new", false},
+		{"recent", false},
+		{"expired", true},
+	} {
+		if got := p.firstDelivery(tc.id, now); got != tc.want {
+			t.Errorf("firstDelivery(%q) = %v, want %v", tc.id, got, tc.want)
+		}
+	}
+}
+
+func TestHandleForgetsFailedDelivery(t *testing.T) {
+	// The issue cannot be read, so the sync fails.
+	client := newTestClient(t, func(req graphqlRequest) string { return "" })
+	p := &plugin{deliveries: map[string]time.Time{}, s: &syncer{client: client}}
+	repo := &github.Repository{Name: github.String("kubernetes"), Owner: &github.User{Login: github.String("kubernetes")}}
+
+	if !p.firstDelivery("1", time.Now()) {
+		t.Fatal("firstDelivery() = false for a new delivery")
+	}
+	p.handle("1", repo, 100)
+	if !p.firstDelivery("1", time.Now()) {
+		t.Error("firstDelivery() = false for the redelivery of a failed sync, want it handled again")
+	}
+}
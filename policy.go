@@ -0,0 +1,193 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// Values of itemPolicy.Action.
+const (
+	policyArchive = "archive"
+	policyMove    = "move"
+	policyPing    = "ping"
+)
+
+// statusRetriage is the status of accepted items that saw no progress for a
+// long time and should be reconsidered at the triage meeting.
+const statusRetriage = "Re-triage"
+
+// defaultPingComment is the comment posted by the ping action when the policy
+// sets none.
+const defaultPingComment = `{assignees} friendly ping: this item has been in {status} on the SIG Auth board for {days} days without an update from you. Are you still working on it? If not, please /unassign so that someone else can pick it up.`
+
+// itemPolicy archives, moves or pings the assignees of board items that have been
+// inactive in a status for a number of days, e.g. to archive Done items after
+// 30 days.
+type itemPolicy struct {
+	// Name identifies the policy in the output.
+	Name string `json:"name"`
+	// Status is the status of the items the policy applies to.
+	Status string `json:"status"`
+	// InactiveDays is the number of days without changes to the item or its
+	// content after which the policy applies.
+	InactiveDays int `json:"inactiveDays,omitempty"`
+	// InactiveMonths is used instead of InactiveDays for long periods, e.g. to
+	// re-triage items accepted a year ago.
+	InactiveMonths int `json:"inactiveMonths,omitempty"`
+	// Action is archive, move, or ping, which comments on items whose
+	// assignees have not commented for InactiveDays.
+	Action string `json:"action"`
+	// To is the status items are moved to by the move action.
+	To string `json:"to,omitempty"`
+	// Comment is posted by the ping action, with {assignees}, {status} and
+	// {days} replaced. Empty means a default friendly ping.
+	Comment string `json:"comment,omitempty"`
+	// DryRun only prints the items the policy would apply to.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+func (p itemPolicy) validate() error {
+	switch {
+	case p.Action != policyArchive && p.Action != policyMove && p.Action != policyPing:
+		return fmt.Errorf("policy %q: unknown action %q, must be one of: %s, %s, %s", p.Name, p.Action, policyArchive, policyMove, policyPing)
+	case p.Action == policyMove && p.To == "":
+		return fmt.Errorf("policy %q: move requires to", p.Name)
+	case (p.InactiveDays > 0) == (p.InactiveMonths > 0) || p.InactiveDays < 0 || p.InactiveMonths < 0:
+		return fmt.Errorf("policy %q: exactly one of inactiveDays and inactiveMonths must be set and positive", p.Name)
+	}
+	return nil
+}
+
+// cutoff returns the time before which items were inactive long enough for
+// the policy to apply.
+func (p itemPolicy) cutoff(now time.Time) time.Time {
+	return now.AddDate(0, -p.InactiveMonths, -p.InactiveDays)
+}
+
+// applyPolicies runs the profile's policies against the items on the board.
+func (s *syncer) applyPolicies(ctx context.Context) error {
+	if len(s.profile.Policies) == 0 {
+		return nil
+	}
+
+	items, err := s.client.listProjectItems(ctx, s.project)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, policy := range s.profile.Policies {
+		cutoff := policy.cutoff(now)
+		for _, item := range items {
+			if item.Status != policy.Status || !item.UpdatedAt.Before(cutoff) {
+				continue
+			}
+			if _, active := snoozed(item.values, now); active {
+				continue
+			}
+			if owner, repo, err := splitRepo(item.Repository); err == nil && !s.filter.includesRepo(owner, repo) {
+				continue
+			}
+
+			prefix := ""
+			if policy.DryRun {
+				prefix = "[dry-run] "
+			}
+			switch policy.Action {
+			case policyArchive:
+				fmt.Printf("%spolicy %q: archiving %s\n", prefix, policy.Name, item.URL)
+				if !policy.DryRun {
+					err = s.client.archiveProjectV2Item(ctx, s.project, item)
+				}
+			case policyMove:
+				fmt.Printf("%spolicy %q: moving %s from %q to %q\n", prefix, policy.Name, item.URL, item.Status, policy.To)
+				if !policy.DryRun {
+					var moved bool
+					moved, err = s.client.setStatus(ctx, s.project, item, policy.To)
+					if moved {
+						s.stats.moved++
+					}
+				}
+			case policyPing:
+				err = s.pingAssignees(ctx, policy, item, cutoff, prefix)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pingAssignees comments on the item mentioning its assignees if none of them
+// commented since cutoff. Items without assignees are skipped.
+func (s *syncer) pingAssignees(ctx context.Context, policy itemPolicy, item *projectItem, cutoff time.Time, prefix string) error {
+	owner, repo, err := splitRepo(item.Repository)
+	if err != nil {
+		// Draft issues have no assignees to ping.
+		return nil
+	}
+	issue, _, err := s.client.Issues.Get(ctx, owner, repo, item.Number)
+	if err != nil {
+		return err
+	}
+	assignees := map[string]bool{}
+	var mentions []string
+	for _, user := range issue.Assignees {
+		assignees[user.GetLogin()] = true
+		mentions = append(mentions, "@"+user.GetLogin())
+	}
+	if len(assignees) == 0 {
+		return nil
+	}
+
+	last, err := s.client.lastComment(ctx, owner, repo, item.Number, func(login string) bool {
+		return assignees[login]
+	})
+	if err != nil {
+		return err
+	}
+	if last.After(cutoff) {
+		return nil
+	}
+
+	fmt.Printf("%spolicy %q: pinging %s on %s\n", prefix, policy.Name, strings.Join(mentions, ", "), item.URL)
+	if policy.DryRun {
+		return nil
+	}
+	comment := policy.Comment
+	if comment == "" {
+		comment = defaultPingComment
+	}
+	body := strings.NewReplacer(
+		"{assignees}", strings.Join(mentions, " "),
+		"{status}", item.Status,
+		"{days}", strconv.Itoa(int(time.Since(cutoff).Hours()/24)),
+	).Replace(comment)
+	_, _, err = s.client.Issues.CreateComment(ctx, owner, repo, item.Number, &github.IssueComment{
+		Body: github.String(body),
+	})
+	s.client.changelog.record(auditEntry{Action: changelogComment, Content: item.URL, After: body}, err)
+	return err
+}
@@ -0,0 +1,411 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+	"sigs.k8s.io/yaml"
+)
+
+// config is the tool configuration, optionally loaded from the file given by --config.
+type config struct {
+	Profiles map[string]profile `json:"profiles"`
+	// BotAuthors are the logins of automation accounts whose items are not imported.
+	BotAuthors []string `json:"botAuthors,omitempty"`
+	// ExemptLabels are labels of items that are never imported, such as
+	// long-running tracking issues. Items already on the board are removed.
+	ExemptLabels []string `json:"exemptLabels,omitempty"`
+	// BranchProtection is the policy the audit-branches command checks the
+	// default branch of subproject repositories against.
+	BranchProtection branchProtectionPolicy `json:"branchProtection,omitempty"`
+	// SeedLabels are the labels the seed-labels command creates in subproject
+	// repositories. Empty means the default labels of label_sync.
+	SeedLabels []labelSpec `json:"seedLabels,omitempty"`
+	// Milestones are the milestones the sync-milestones command maintains in
+	// subproject repositories.
+	Milestones []milestoneSpec `json:"milestones,omitempty"`
+	// ReleaseSchedule is the schedule of the release in progress, used to
+	// alert on approaching freezes.
+	ReleaseSchedule *releaseSchedule `json:"releaseSchedule,omitempty"`
+}
+
+// profile describes a project board and how items are synced into it.
+type profile struct {
+	// Project is the title of the project board.
+	Project string `json:"project"`
+	// PullRequestsOnly restricts the sync to pull requests.
+	PullRequestsOnly bool `json:"pullRequestsOnly,omitempty"`
+	// Sources are the places items are imported from.
+	Sources []source `json:"sources"`
+	// Areas maps area/* labels to options of the Area field.
+	Areas map[string]string `json:"areas,omitempty"`
+	// LabelStatuses maps labels to the initial status of items carrying them,
+	// overriding the initial status of the source.
+	LabelStatuses map[string]string `json:"labelStatuses,omitempty"`
+	// RepoStatuses maps owner/name repositories to the initial status of their
+	// items, overriding both label routing and the initial status of the source.
+	RepoStatuses map[string]string `json:"repoStatuses,omitempty"`
+	// CISignalContact is the GitHub login mentioned on items moved to the CI
+	// Signal status. Empty means nobody is pinged.
+	CISignalContact string `json:"ciSignalContact,omitempty"`
+	// ExceptionLabels are labels marking freeze exception requests, in
+	// addition to titles such as "Exception request". Those are moved to the
+	// Exceptions status.
+	ExceptionLabels []string `json:"exceptionLabels,omitempty"`
+	// NeedsInformation, if set, moves kind/bug issues that leave sections of
+	// the bug report template empty or do not state the Kubernetes version to
+	// the Needs Information status.
+	NeedsInformation *needsInformationPolicy `json:"needsInformation,omitempty"`
+	// Policies archive or move items that have been inactive in a status, and
+	// run at the end of each sync.
+	Policies []itemPolicy `json:"policies,omitempty"`
+	// ItemLimit is the maximum number of active items of the project. Zero
+	// means the GitHub limit.
+	ItemLimit int `json:"itemLimit,omitempty"`
+	// ItemWarningPercent is the share of ItemLimit, in percent, from which the
+	// sync warns about the item count. Zero means 90.
+	ItemWarningPercent int `json:"itemWarningPercent,omitempty"`
+	// TrackingIssue is the issue listing untriaged items, maintained by the
+	// tracking-issue command.
+	TrackingIssue *issueRef `json:"trackingIssue,omitempty"`
+	// TriageMeeting is the recurring meeting the meeting-issue command opens
+	// an agenda issue for.
+	TriageMeeting *triageMeeting `json:"triageMeeting,omitempty"`
+	// ReleaseFollowUp is the task list the release-followups command opens for
+	// new subproject releases.
+	ReleaseFollowUp *releaseFollowUp `json:"releaseFollowUp,omitempty"`
+	// APIReview, if set, imports the PRs of the API review queue that change
+	// auth API types into the API Review status.
+	APIReview *apiReview `json:"apiReview,omitempty"`
+	// Summarizer, if set, generates the Summary field of newly imported items.
+	Summarizer *summarizer `json:"summarizer,omitempty"`
+	// FieldMappings declare additional fields derived from the item content.
+	FieldMappings []fieldMapping `json:"fields,omitempty"`
+	// SnapshotExport is the file the board snapshot is committed to after each
+	// sync, giving a versioned history of the board.
+	SnapshotExport *repoFile `json:"snapshotExport,omitempty"`
+	// WeeklyReport is the file the weekly-report command opens a PR for. The
+	// path may contain {date}, replaced with the report date.
+	WeeklyReport *repoFile `json:"weeklyReport,omitempty"`
+	// RunLogIssue is the issue a summary of each sync run is posted to, so the
+	// community can follow the automation without Slack.
+	RunLogIssue *issueRef `json:"runLogIssue,omitempty"`
+	// BigQueryExport is the table per-item records are streamed to after each
+	// sync, for analytics alongside the devstats datasets.
+	BigQueryExport *bigQueryTable `json:"bigQueryExport,omitempty"`
+}
+
+// repoFile identifies a file in a repository.
+type repoFile struct {
+	// Repo is the repository, in owner/name form.
+	Repo string `json:"repo"`
+	Path string `json:"path"`
+	// Branch is the branch to commit to. Empty means the default branch.
+	Branch string `json:"branch,omitempty"`
+}
+
+// issueRef identifies an issue by its repository and title.
+type issueRef struct {
+	// Repo is the repository of the issue, in owner/name form.
+	Repo  string `json:"repo"`
+	Title string `json:"title"`
+}
+
+// split returns the owner and name of the repository.
+func (r issueRef) split() (owner, repo string, err error) {
+	return splitRepo(r.Repo)
+}
+
+// splitRepo splits a repository in owner/name form.
+func splitRepo(nameWithOwner string) (owner, repo string, err error) {
+	owner, repo, ok := strings.Cut(nameWithOwner, "/")
+	if !ok {
+		return "", "", fmt.Errorf("invalid repository %q, must be of the form owner/name", nameWithOwner)
+	}
+	return owner, repo, nil
+}
+
+// source is an organization, or a list of repositories, searched for labeled
+// items.
+type source struct {
+	// Org is the GitHub organization to search.
+	Org string `json:"org,omitempty"`
+	// Repos, if set, are the repositories to search, in owner/name form,
+	// instead of those of Org. This covers SIG Auth related repositories in
+	// other organizations.
+	Repos []string `json:"repos,omitempty"`
+	// Topics restricts the search to repositories with one of these topics.
+	// Empty means all repositories of the organization.
+	Topics []string `json:"topics,omitempty"`
+	// Labels are the labels items must carry to be imported. Empty means all
+	// open items.
+	Labels []string `json:"labels,omitempty"`
+	// IssueStatus is the status of newly imported issues.
+	IssueStatus string `json:"issueStatus"`
+	// PullRequestStatus is the status of newly imported PRs.
+	PullRequestStatus string `json:"pullRequestStatus"`
+	// RepositoryGroup sets the Repository group field to the repository name,
+	// so that items can be grouped by subproject.
+	RepositoryGroup bool `json:"repositoryGroup,omitempty"`
+	// AutomationStatus, if set, is the status PRs of botAuthors, such as
+	// dependency updates, are imported into instead of being excluded, so
+	// that they can be reviewed in batches.
+	AutomationStatus string `json:"automationStatus,omitempty"`
+}
+
+// defaultProfile is the profile used when --profile is not set.
+const defaultProfile = "triage"
+
+// newDefaultConfig returns the built-in config. Values set in the file given by
+// --config override it.
+func newDefaultConfig() *config {
+	return &config{
+		BotAuthors: []string{
+			"k8s-ci-robot",
+			"dependabot[bot]",
+			"renovate[bot]",
+		},
+		Profiles: map[string]profile{
+			// triage is the main SIG Auth board covering all issues and PRs.
+			"triage": {
+				Project: projectName,
+				Sources: []source{
+					{
+						Org:               orgName,
+						Labels:            []string{"sig/auth"},
+						IssueStatus:       statusNeedsTriage,
+						PullRequestStatus: statusPRsNeedsReview,
+					},
+					{
+						Org:               "kubernetes-sigs",
+						Topics:            []string{"k8s-sig-auth"},
+						IssueStatus:       statusSubprojectsNeedsTriage,
+						PullRequestStatus: statusSubprojectsNeedsTriage,
+						RepositoryGroup:   true,
+					},
+				},
+				Areas: map[string]string{
+					"area/audit":          "Audit",
+					"area/certificates":   "Certificates",
+					"area/security":       "Security",
+					"area/serviceaccount": "Service Accounts",
+				},
+				LabelStatuses: map[string]string{
+					"wg/structured-auth": "WG Structured Auth - Needs Triage",
+					"wg/policy":          "WG Policy - Needs Triage",
+					"kind/deprecation":   statusDeprecations,
+					"kind/failing-test":  statusCISignal,
+				},
+				RepoStatuses: map[string]string{
+					"kubernetes/website": statusDocsNeedsTriage,
+				},
+				WeeklyReport: &repoFile{
+					Repo: "kubernetes/community",
+					Path: "sig-auth/triage-reports/{date}.md",
+				},
+				FieldMappings: []fieldMapping{
+					{
+						Field:   "Priority",
+						From:    mappingFromLabel,
+						Pattern: "^priority/(.*)$",
+						Map: map[string]string{
+							"critical-urgent":        "Critical Urgent",
+							"important-soon":         "Important Soon",
+							"important-longterm":     "Important Longterm",
+							"backlog":                "Backlog",
+							"awaiting-more-evidence": "Awaiting More Evidence",
+						},
+					},
+					{
+						Field:   "Kind",
+						From:    mappingFromLabel,
+						Pattern: "^kind/(.*)$",
+					},
+					{
+						Field:   "Docs",
+						From:    mappingFromRepository,
+						Pattern: "^kubernetes/website$",
+						Map:     map[string]string{"kubernetes/website": "Yes"},
+					},
+				},
+			},
+			// pr-review is a review board covering open PRs only.
+			"pr-review": {
+				Project:          "SIG Auth PR Review",
+				PullRequestsOnly: true,
+				Sources: []source{
+					{
+						Org:               orgName,
+						Labels:            []string{"sig/auth"},
+						PullRequestStatus: statusNeedsReview,
+					},
+				},
+			},
+		},
+	}
+}
+
+func loadConfig(path string) (*config, error) {
+	cfg := newDefaultConfig()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing config %q: %w", path, err)
+		}
+	}
+
+	if err := cfg.compile(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// compile validates the config and prepares it for use.
+func (c *config) compile() error {
+	for name, p := range c.Profiles {
+		for _, src := range p.Sources {
+			if (src.Org == "") == (len(src.Repos) == 0) {
+				return fmt.Errorf("profile %q: each source must set exactly one of org and repos", name)
+			}
+			for _, repo := range src.Repos {
+				if _, _, err := splitRepo(repo); err != nil {
+					return fmt.Errorf("profile %q: %w", name, err)
+				}
+			}
+		}
+		for i := range p.FieldMappings {
+			if err := p.FieldMappings[i].compile(); err != nil {
+				return fmt.Errorf("profile %q: %w", name, err)
+			}
+		}
+		for _, policy := range p.Policies {
+			if err := policy.validate(); err != nil {
+				return fmt.Errorf("profile %q: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *config) getProfile(name string) (profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("unknown profile %q, must be one of: %s", name, strings.Join(c.profileNames(), ", "))
+	}
+	return p, nil
+}
+
+func (c *config) profileNames() []string {
+	var names []string
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// name returns a short name of the source for logs and rule names.
+func (s source) name() string {
+	if s.Org != "" {
+		return s.Org
+	}
+	return strings.Join(s.Repos, ",")
+}
+
+// includesRepo reports whether the repository is searched by the source.
+func (s source) includesRepo(repo *github.Repository) bool {
+	if len(s.Repos) > 0 {
+		for _, nameWithOwner := range s.Repos {
+			if strings.EqualFold(repo.GetFullName(), nameWithOwner) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(s.Topics) == 0 {
+		return true
+	}
+	for _, topic := range repo.Topics {
+		for _, want := range s.Topics {
+			if topic == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// initialStatus returns the status newly imported items from the source start in.
+func (s source) initialStatus(issue *github.Issue) string {
+	if issue.IsPullRequest() {
+		return s.PullRequestStatus
+	}
+	return s.IssueStatus
+}
+
+// initialStatus returns the status a newly imported item starts in, taking
+// repository and label routing into account.
+func (p profile) initialStatus(src source, issue *github.Issue) string {
+	owner, repo := issueRepo(issue)
+	if status, ok := p.RepoStatuses[owner+"/"+repo]; ok {
+		return status
+	}
+	for _, label := range issue.Labels {
+		if status, ok := p.LabelStatuses[label.GetName()]; ok {
+			return status
+		}
+	}
+	return src.initialStatus(issue)
+}
+
+// isReconcilable reports whether items in status are managed by the tool. Items
+// in any other status have been moved by a human and are left alone.
+func (p profile) isReconcilable(status string) bool {
+	return derivedStatuses[status] || p.isUntriaged(status)
+}
+
+// isUntriaged reports whether items in status have not been triaged yet, i.e.
+// they are still in an initial status.
+func (p profile) isUntriaged(status string) bool {
+	if status == "" {
+		return true
+	}
+	for _, src := range p.Sources {
+		if status == src.IssueStatus || status == src.PullRequestStatus {
+			return true
+		}
+	}
+	for _, labelStatus := range p.LabelStatuses {
+		if status == labelStatus {
+			return true
+		}
+	}
+	for _, repoStatus := range p.RepoStatuses {
+		if status == repoStatus {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,755 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// project is a GitHub ProjectV2 board along with its field definitions.
+type project struct {
+	ID     githubql.ID
+	Title  string
+	fields map[string]*projectField
+}
+
+// projectField is a field defined on a ProjectV2 board.
+type projectField struct {
+	ID       githubql.ID
+	Name     string
+	DataType string
+	// options maps single select option names to their IDs, and optionNames
+	// lists them in board order.
+	options     map[string]githubql.String
+	optionNames []string
+	// iterations are the active and upcoming iterations of an iteration field,
+	// and completedIterations the past ones.
+	iterations          []projectIteration
+	completedIterations []projectIteration
+}
+
+// allIterations returns the completed, active and upcoming iterations of the
+// field. Updates of the iteration configuration must include all of them, as
+// the iterations left out are deleted.
+func (f *projectField) allIterations() []projectIteration {
+	iterations := append([]projectIteration{}, f.completedIterations...)
+	return append(iterations, f.iterations...)
+}
+
+// projectIteration is an iteration of an iteration field.
+type projectIteration struct {
+	ID        githubql.String
+	Title     string
+	StartDate string
+	// Duration is the length of the iteration in days.
+	Duration int
+}
+
+// projectItem is an item on a ProjectV2 board.
+type projectItem struct {
+	ID     githubql.ID
+	Status string
+	// StatusChangedAt is the time Status was last set, zero if it is not set.
+	StatusChangedAt time.Time
+	// values are the current custom field values by field name, nil if unknown.
+	values map[string]string
+	// added is set when the item was just added by addProjectV2ItemById.
+	added bool
+
+	// The fields below are set by listProjectItems. addProjectV2ItemById only
+	// sets ContentID and URL.
+
+	// Type is the item type, one of ISSUE, PULL_REQUEST, DRAFT_ISSUE or REDACTED.
+	Type githubql.ProjectV2ItemType
+	// ContentID is the node ID of the issue or PR, empty for draft issues.
+	ContentID  string
+	Repository string
+	Number     int
+	Title      string
+	URL        string
+	// State is the issue or PR state, e.g. OPEN, CLOSED or MERGED.
+	State     string
+	CreatedAt time.Time
+	// UpdatedAt is the time of the latest change to the item content or its
+	// fields, other than the computedFields.
+	UpdatedAt time.Time
+	Labels    []string
+}
+
+// projectItemContent are the content fields shared between issues and PRs.
+type projectItemContent struct {
+	ID         githubql.ID       `graphql:"id"`
+	Number     githubql.Int      `graphql:"number"`
+	Title      githubql.String   `graphql:"title"`
+	URL        githubql.URI      `graphql:"url"`
+	CreatedAt  githubql.DateTime `graphql:"createdAt"`
+	UpdatedAt  githubql.DateTime `graphql:"updatedAt"`
+	Repository struct {
+		NameWithOwner githubql.String `graphql:"nameWithOwner"`
+	} `graphql:"repository"`
+	Labels struct {
+		Nodes []struct {
+			Name githubql.String `graphql:"name"`
+		} `graphql:"nodes"`
+	} `graphql:"labels(first: 50)"`
+}
+
+// listProjectItems returns all items on the project.
+func (c *ghClient) listProjectItems(ctx context.Context, p *project) ([]*projectItem, error) {
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Items struct {
+					Nodes []struct {
+						ID          githubql.ID                `graphql:"id"`
+						Type        githubql.ProjectV2ItemType `graphql:"type"`
+						FieldValues itemFieldValues            `graphql:"fieldValues(first: 50)"`
+						Content     struct {
+							Issue struct {
+								projectItemContent
+								State githubql.String `graphql:"state"`
+							} `graphql:"... on Issue"`
+							PullRequest struct {
+								projectItemContent
+								// Aliased since the state enums of issues and PRs differ.
+								State githubql.String `graphql:"prState: state"`
+							} `graphql:"... on PullRequest"`
+							DraftIssue struct {
+								Title githubql.String `graphql:"title"`
+							} `graphql:"... on DraftIssue"`
+						} `graphql:"content"`
+					} `graphql:"nodes"`
+					PageInfo struct {
+						HasNextPage githubql.Boolean `graphql:"hasNextPage"`
+						EndCursor   githubql.String  `graphql:"endCursor"`
+					} `graphql:"pageInfo"`
+				} `graphql:"items(first: $perPage, after: $cursor)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	variables := map[string]interface{}{
+		"id":      p.ID,
+		"perPage": githubql.Int(perPage),
+		"cursor":  (*githubql.String)(nil),
+	}
+
+	var items []*projectItem
+	for {
+		query.Node.ProjectV2.Items.Nodes = nil
+		err := c.v4Client.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range query.Node.ProjectV2.Items.Nodes {
+			values := node.FieldValues.values()
+			item := &projectItem{
+				ID:              node.ID,
+				Status:          values[statusFieldName],
+				StatusChangedAt: node.FieldValues.updatedAt(statusFieldName),
+				values:          values,
+				Type:            node.Type,
+				UpdatedAt:       node.FieldValues.lastUpdate(),
+				Title:           string(node.Content.DraftIssue.Title),
+			}
+			content, state := node.Content.Issue.projectItemContent, node.Content.Issue.State
+			if node.Type == githubql.ProjectV2ItemTypePullRequest {
+				content, state = node.Content.PullRequest.projectItemContent, node.Content.PullRequest.State
+			}
+			if content.ID != nil {
+				item.ContentID = fmt.Sprint(content.ID)
+				item.Repository = string(content.Repository.NameWithOwner)
+				item.Number = int(content.Number)
+				item.Title = string(content.Title)
+				item.URL = content.URL.String()
+				item.State = string(state)
+				item.CreatedAt = content.CreatedAt.Time
+				if content.UpdatedAt.After(item.UpdatedAt) {
+					item.UpdatedAt = content.UpdatedAt.Time
+				}
+				for _, label := range content.Labels.Nodes {
+					item.Labels = append(item.Labels, string(label.Name))
+				}
+			}
+			items = append(items, item)
+		}
+		if !query.Node.ProjectV2.Items.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubql.NewString(query.Node.ProjectV2.Items.PageInfo.EndCursor)
+	}
+
+	return items, nil
+}
+
+// countProjectItems returns the number of active, i.e. not archived, items on the project.
+func (c *ghClient) countProjectItems(ctx context.Context, p *project) (int, error) {
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Items struct {
+					TotalCount githubql.Int `graphql:"totalCount"`
+				} `graphql:"items"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": p.ID,
+	}
+
+	if err := c.v4Client.Query(ctx, &query, variables); err != nil {
+		return 0, err
+	}
+	return int(query.Node.ProjectV2.Items.TotalCount), nil
+}
+
+// itemsByContentID indexes items by the node ID of their issue or PR.
+func itemsByContentID(items []*projectItem) map[string]*projectItem {
+	index := make(map[string]*projectItem, len(items))
+	for _, item := range items {
+		if item.ContentID != "" {
+			index[item.ContentID] = item
+		}
+	}
+	return index
+}
+
+func (c *ghClient) getProject(ctx context.Context, org, name string) (*project, error) {
+	projectID, err := c.getProjectID(ctx, org, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := c.getProjectFields(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &project{ID: projectID, Title: name, fields: fields}, nil
+}
+
+func (c *ghClient) getProjectFields(ctx context.Context, projectID githubql.ID) (map[string]*projectField, error) {
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Fields struct {
+					Nodes []struct {
+						Common struct {
+							ID       githubql.ID     `graphql:"id"`
+							Name     githubql.String `graphql:"name"`
+							DataType githubql.String `graphql:"dataType"`
+						} `graphql:"... on ProjectV2FieldCommon"`
+						SingleSelect struct {
+							Options []struct {
+								ID   githubql.String `graphql:"id"`
+								Name githubql.String `graphql:"name"`
+							} `graphql:"options"`
+						} `graphql:"... on ProjectV2SingleSelectField"`
+						Iteration struct {
+							Configuration struct {
+								Iterations []struct {
+									ID        githubql.String `graphql:"id"`
+									Title     githubql.String `graphql:"title"`
+									StartDate githubql.String `graphql:"startDate"`
+									Duration  githubql.Int    `graphql:"duration"`
+								} `graphql:"iterations"`
+								CompletedIterations []struct {
+									ID        githubql.String `graphql:"id"`
+									Title     githubql.String `graphql:"title"`
+									StartDate githubql.String `graphql:"startDate"`
+									Duration  githubql.Int    `graphql:"duration"`
+								} `graphql:"completedIterations"`
+							} `graphql:"configuration"`
+						} `graphql:"... on ProjectV2IterationField"`
+					} `graphql:"nodes"`
+				} `graphql:"fields(first: 100)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	variables := map[string]interface{}{
+		"id": projectID,
+	}
+
+	err := c.v4Client.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]*projectField)
+	for _, node := range query.Node.ProjectV2.Fields.Nodes {
+		field := &projectField{
+			ID:       node.Common.ID,
+			Name:     string(node.Common.Name),
+			DataType: string(node.Common.DataType),
+			options:  make(map[string]githubql.String),
+		}
+		for _, option := range node.SingleSelect.Options {
+			field.options[string(option.Name)] = option.ID
+			field.optionNames = append(field.optionNames, string(option.Name))
+		}
+		for _, iteration := range node.Iteration.Configuration.Iterations {
+			field.iterations = append(field.iterations, projectIteration{
+				ID:        iteration.ID,
+				Title:     string(iteration.Title),
+				StartDate: string(iteration.StartDate),
+				Duration:  int(iteration.Duration),
+			})
+		}
+		for _, iteration := range node.Iteration.Configuration.CompletedIterations {
+			field.completedIterations = append(field.completedIterations, projectIteration{
+				ID:        iteration.ID,
+				Title:     string(iteration.Title),
+				StartDate: string(iteration.StartDate),
+				Duration:  int(iteration.Duration),
+			})
+		}
+		fields[field.Name] = field
+	}
+
+	return fields, nil
+}
+
+// itemFieldValues selects the custom field values of an item.
+type itemFieldValues struct {
+	Nodes []struct {
+		Typename githubql.String `graphql:"__typename"`
+		Common   struct {
+			Field struct {
+				Common struct {
+					Name githubql.String `graphql:"name"`
+				} `graphql:"... on ProjectV2FieldCommon"`
+			} `graphql:"field"`
+			UpdatedAt githubql.DateTime `graphql:"updatedAt"`
+		} `graphql:"... on ProjectV2ItemFieldValueCommon"`
+		SingleSelect struct {
+			Name githubql.String `graphql:"name"`
+		} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+		Text struct {
+			Text githubql.String `graphql:"text"`
+		} `graphql:"... on ProjectV2ItemFieldTextValue"`
+		Number struct {
+			Number githubql.Float `graphql:"number"`
+		} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+		Date struct {
+			Date githubql.String `graphql:"date"`
+		} `graphql:"... on ProjectV2ItemFieldDateValue"`
+		Iteration struct {
+			Title githubql.String `graphql:"title"`
+		} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+	} `graphql:"nodes"`
+}
+
+// values returns the field values by field name, formatted as by formatNumber
+// for numbers, as YYYY-MM-DD for dates and as the iteration title for
+// iterations.
+func (v itemFieldValues) values() map[string]string {
+	values := make(map[string]string)
+	for _, node := range v.Nodes {
+		name := string(node.Common.Field.Common.Name)
+		switch node.Typename {
+		case "ProjectV2ItemFieldSingleSelectValue":
+			values[name] = string(node.SingleSelect.Name)
+		case "ProjectV2ItemFieldTextValue":
+			values[name] = string(node.Text.Text)
+		case "ProjectV2ItemFieldNumberValue":
+			values[name] = formatNumber(float64(node.Number.Number))
+		case "ProjectV2ItemFieldDateValue":
+			values[name] = string(node.Date.Date)
+		case "ProjectV2ItemFieldIterationValue":
+			values[name] = string(node.Iteration.Title)
+		}
+	}
+	return values
+}
+
+// updatedAt returns the time the named field was last set, or the zero time if
+// it is not set.
+func (v itemFieldValues) updatedAt(fieldName string) time.Time {
+	for _, node := range v.Nodes {
+		if string(node.Common.Field.Common.Name) == fieldName {
+			return node.Common.UpdatedAt.Time
+		}
+	}
+	return time.Time{}
+}
+
+// lastUpdate returns the time the latest of the fields, other than the
+// computedFields, was set.
+func (v itemFieldValues) lastUpdate() time.Time {
+	var last time.Time
+	for _, node := range v.Nodes {
+		if computedFields[string(node.Common.Field.Common.Name)] {
+			continue
+		}
+		if node.Common.UpdatedAt.After(last) {
+			last = node.Common.UpdatedAt.Time
+		}
+	}
+	return last
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// addProjectV2ItemById adds the content to the project and returns the resulting
+// item along with its current field values. If the content is already on the
+// project, the existing item is returned.
+func (c *ghClient) addProjectV2ItemById(ctx context.Context, p *project, contentID githubql.ID) (*projectItem, error) {
+	// xref: https://docs.github.com/en/issues/planning-and-tracking-with-projects/automating-your-project/using-the-api-to-manage-projects#adding-an-item-to-a-project
+	var mutation struct {
+		AddProjectV2ItemById struct {
+			Item struct {
+				ID          githubql.ID       `graphql:"id"`
+				CreatedAt   githubql.DateTime `graphql:"createdAt"`
+				FieldValues itemFieldValues   `graphql:"fieldValues(first: 50)"`
+				Content     struct {
+					Issue struct {
+						URL githubql.URI `graphql:"url"`
+					} `graphql:"... on Issue"`
+					PullRequest struct {
+						URL githubql.URI `graphql:"url"`
+					} `graphql:"... on PullRequest"`
+				} `graphql:"content"`
+			} `graphql:"item"`
+		} `graphql:"addProjectV2ItemById(input: $input)"`
+	}
+	input := githubql.AddProjectV2ItemByIdInput{
+		ProjectID: p.ID,
+		ContentID: contentID,
+	}
+
+	// Allow for clock skew between us and GitHub when telling new items apart.
+	start := time.Now().Add(-time.Minute)
+	if err := c.v4Client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return nil, err
+	}
+
+	node := mutation.AddProjectV2ItemById.Item
+	values := node.FieldValues.values()
+	item := &projectItem{
+		ID:              node.ID,
+		Status:          values[statusFieldName],
+		StatusChangedAt: node.FieldValues.updatedAt(statusFieldName),
+		values:          values,
+		ContentID:       fmt.Sprint(contentID),
+		URL:             node.Content.Issue.URL.String(),
+		added:           node.CreatedAt.After(start),
+	}
+	if item.URL == "" {
+		item.URL = node.Content.PullRequest.URL.String()
+	}
+	if item.added {
+		c.recordMutation(auditEntry{Action: auditAdd, Project: p.Title, ItemID: fmt.Sprint(item.ID), Content: item.URL})
+	}
+	return item, nil
+}
+
+func (c *ghClient) updateProjectV2ItemFieldValue(ctx context.Context, projectID, itemID, fieldID githubql.ID, value githubql.ProjectV2FieldValue) error {
+	// xref: https://docs.github.com/en/issues/planning-and-tracking-with-projects/automating-your-project/using-the-api-to-manage-projects#updating-a-custom-single-select-field
+	var mutation struct {
+		UpdateProjectV2ItemFieldValue struct {
+			ProjectV2Item struct {
+				ID githubql.ID `graphql:"id"`
+			} `graphql:"projectV2Item"`
+		} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+	}
+	input := githubql.UpdateProjectV2ItemFieldValueInput{
+		ProjectID: projectID,
+		ItemID:    itemID,
+		FieldID:   fieldID,
+		Value:     value,
+	}
+
+	return c.v4Client.Mutate(ctx, &mutation, input, nil)
+}
+
+// hasField reports whether the project defines the named field.
+func (p *project) hasField(name string) bool {
+	_, ok := p.fields[name]
+	return ok
+}
+
+// hasOption reports whether the project defines the named single select field
+// with the given option.
+func (p *project) hasOption(fieldName, optionName string) bool {
+	field, ok := p.fields[fieldName]
+	if !ok {
+		return false
+	}
+	_, ok = field.options[optionName]
+	return ok
+}
+
+// setItemField sets the field on item to value, whose formatted form is
+// formatted, and reports whether the update was applied, queued or planned.
+// The mutation is skipped when the item already has that value, or when the
+// field changed since the item was read, see skipStaleMutation.
+func (c *ghClient) setItemField(ctx context.Context, p *project, item *projectItem, fieldName string, value githubql.ProjectV2FieldValue, formatted string) (bool, error) {
+	field, ok := p.fields[fieldName]
+	if !ok {
+		return false, fmt.Errorf("field %q not found in project %q", fieldName, p.Title)
+	}
+	if current, ok := item.values[fieldName]; ok && current == formatted {
+		return false, nil
+	}
+	if skip, err := c.skipStaleMutation(ctx, p, item, fieldName, formatted); err != nil || skip {
+		return false, err
+	}
+
+	entry := auditEntry{
+		Action:  auditUpdate,
+		Project: p.Title,
+		ItemID:  fmt.Sprint(item.ID),
+		Content: item.URL,
+		Field:   fieldName,
+		Before:  item.values[fieldName],
+		After:   formatted,
+	}
+	switch {
+	case c.plan != nil:
+		c.plan.add(entry)
+	case c.queue != nil:
+		// The item is updated locally right away, so that later decisions of
+		// the run see the queued value.
+		if err := c.queueUpdate(ctx, pendingUpdate{
+			input: githubql.UpdateProjectV2ItemFieldValueInput{
+				ProjectID: p.ID,
+				ItemID:    item.ID,
+				FieldID:   field.ID,
+				Value:     value,
+			},
+			entry: entry,
+		}); err != nil {
+			return false, err
+		}
+	default:
+		if err := c.updateProjectV2ItemFieldValue(ctx, p.ID, item.ID, field.ID, value); err != nil {
+			return false, err
+		}
+		c.recordMutation(entry)
+	}
+	if item.values != nil {
+		item.values[fieldName] = formatted
+	}
+	if fieldName == statusFieldName {
+		item.Status, item.StatusChangedAt = formatted, time.Now()
+	}
+	return true, nil
+}
+
+// setSingleSelectField sets the single select field on item to the named option.
+func (c *ghClient) setSingleSelectField(ctx context.Context, p *project, item *projectItem, fieldName, optionName string) error {
+	_, err := c.setSingleSelectOption(ctx, p, item, fieldName, optionName)
+	return err
+}
+
+// setStatus moves item to status and reports whether it was moved, see
+// setItemField. Callers only follow up on moves that were made, e.g. with a
+// comment, as a concurrent run or a human may have moved the item first.
+func (c *ghClient) setStatus(ctx context.Context, p *project, item *projectItem, status string) (bool, error) {
+	return c.setSingleSelectOption(ctx, p, item, statusFieldName, status)
+}
+
+func (c *ghClient) setSingleSelectOption(ctx context.Context, p *project, item *projectItem, fieldName, optionName string) (bool, error) {
+	field, ok := p.fields[fieldName]
+	if !ok {
+		return false, fmt.Errorf("field %q not found in project %q", fieldName, p.Title)
+	}
+	optionID, ok := field.options[optionName]
+	if !ok {
+		return false, fmt.Errorf("option %q not found for field %q in project %q", optionName, fieldName, p.Title)
+	}
+
+	return c.setItemField(ctx, p, item, fieldName, githubql.ProjectV2FieldValue{
+		SingleSelectOptionID: &optionID,
+	}, optionName)
+}
+
+// setNumberField sets the number field on item to value.
+func (c *ghClient) setNumberField(ctx context.Context, p *project, item *projectItem, fieldName string, value float64) error {
+	number := githubql.Float(value)
+	_, err := c.setItemField(ctx, p, item, fieldName, githubql.ProjectV2FieldValue{
+		Number: &number,
+	}, formatNumber(value))
+	return err
+}
+
+// setTextField sets the text field on item to value.
+func (c *ghClient) setTextField(ctx context.Context, p *project, item *projectItem, fieldName, value string) error {
+	text := githubql.String(value)
+	_, err := c.setItemField(ctx, p, item, fieldName, githubql.ProjectV2FieldValue{
+		Text: &text,
+	}, value)
+	return err
+}
+
+// setDateField sets the date field on item to the day of value.
+func (c *ghClient) setDateField(ctx context.Context, p *project, item *projectItem, fieldName string, value time.Time) error {
+	_, err := c.setItemField(ctx, p, item, fieldName, githubql.ProjectV2FieldValue{
+		Date: githubql.NewDate(githubql.Date{Time: value}),
+	}, value.Format(dateFormat))
+	return err
+}
+
+// setIterationField sets the iteration field on item to the iteration with the
+// given title.
+func (c *ghClient) setIterationField(ctx context.Context, p *project, item *projectItem, fieldName, title string) error {
+	field, ok := p.fields[fieldName]
+	if !ok {
+		return fmt.Errorf("field %q not found in project %q", fieldName, p.Title)
+	}
+	for _, iteration := range field.iterations {
+		if iteration.Title == title {
+			id := iteration.ID
+			_, err := c.setItemField(ctx, p, item, fieldName, githubql.ProjectV2FieldValue{
+				IterationID: &id,
+			}, title)
+			return err
+		}
+	}
+	return fmt.Errorf("iteration %q not found in field %q of project %q", title, fieldName, p.Title)
+}
+
+// dateFormat is the format of date field values.
+const dateFormat = "2006-01-02"
+
+// clearField removes the value of the field on item. The mutation is skipped
+// when the item is known to have no value.
+func (c *ghClient) clearField(ctx context.Context, p *project, item *projectItem, fieldName string) error {
+	field, ok := p.fields[fieldName]
+	if !ok {
+		return fmt.Errorf("field %q not found in project %q", fieldName, p.Title)
+	}
+	if _, ok := item.values[fieldName]; !ok && item.values != nil {
+		return nil
+	}
+	if skip, err := c.skipStaleMutation(ctx, p, item, fieldName, ""); err != nil || skip {
+		return err
+	}
+	entry := auditEntry{
+		Action:  auditClear,
+		Project: p.Title,
+		ItemID:  fmt.Sprint(item.ID),
+		Content: item.URL,
+		Field:   fieldName,
+		Before:  item.values[fieldName],
+	}
+	input := githubql.ClearProjectV2ItemFieldValueInput{
+		ProjectID: p.ID,
+		ItemID:    item.ID,
+		FieldID:   field.ID,
+	}
+	switch {
+	case c.plan != nil:
+		c.plan.add(entry)
+	case c.queue != nil:
+		if err := c.queueUpdate(ctx, pendingUpdate{input: input, entry: entry}); err != nil {
+			return err
+		}
+	default:
+		var mutation struct {
+			ClearProjectV2ItemFieldValue struct {
+				ProjectV2Item struct {
+					ID githubql.ID `graphql:"id"`
+				} `graphql:"projectV2Item"`
+			} `graphql:"clearProjectV2ItemFieldValue(input: $input)"`
+		}
+		if err := c.v4Client.Mutate(ctx, &mutation, input, nil); err != nil {
+			return err
+		}
+		c.recordMutation(entry)
+	}
+	delete(item.values, fieldName)
+	return nil
+}
+
+// archiveProjectV2Item archives the item, hiding it from the board views.
+func (c *ghClient) archiveProjectV2Item(ctx context.Context, p *project, item *projectItem) error {
+	var mutation struct {
+		ArchiveProjectV2Item struct {
+			Item struct {
+				ID githubql.ID `graphql:"id"`
+			} `graphql:"item"`
+		} `graphql:"archiveProjectV2Item(input: $input)"`
+	}
+	input := githubql.ArchiveProjectV2ItemInput{
+		ProjectID: p.ID,
+		ItemID:    item.ID,
+	}
+
+	if err := c.v4Client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return err
+	}
+	c.recordMutation(auditEntry{Action: auditArchive, Project: p.Title, ItemID: fmt.Sprint(item.ID), Content: item.URL})
+	return nil
+}
+
+// deleteProjectV2Item removes the item from the project.
+func (c *ghClient) deleteProjectV2Item(ctx context.Context, p *project, item *projectItem) error {
+	var mutation struct {
+		DeleteProjectV2Item struct {
+			DeletedItemID githubql.ID `graphql:"deletedItemId"`
+		} `graphql:"deleteProjectV2Item(input: $input)"`
+	}
+	input := githubql.DeleteProjectV2ItemInput{
+		ProjectID: p.ID,
+		ItemID:    item.ID,
+	}
+
+	if err := c.v4Client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return err
+	}
+	c.recordMutation(auditEntry{Action: auditDelete, Project: p.Title, ItemID: fmt.Sprint(item.ID), Content: item.URL})
+	return nil
+}
+
+// setFieldValue sets the field on item from its string representation,
+// according to the field's data type. Dates use the YYYY-MM-DD format.
+func (c *ghClient) setFieldValue(ctx context.Context, p *project, item *projectItem, fieldName, value string) error {
+	field, ok := p.fields[fieldName]
+	if !ok {
+		return fmt.Errorf("field %q not found in project %q", fieldName, p.Title)
+	}
+
+	switch githubql.ProjectV2FieldType(field.DataType) {
+	case githubql.ProjectV2FieldTypeSingleSelect:
+		return c.setSingleSelectField(ctx, p, item, fieldName, value)
+	case githubql.ProjectV2FieldTypeText:
+		return c.setTextField(ctx, p, item, fieldName, value)
+	case githubql.ProjectV2FieldTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", fieldName, err)
+		}
+		return c.setNumberField(ctx, p, item, fieldName, number)
+	case githubql.ProjectV2FieldTypeDate:
+		date, err := time.Parse(dateFormat, value)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", fieldName, err)
+		}
+		return c.setDateField(ctx, p, item, fieldName, date)
+	default:
+		return fmt.Errorf("field %q has unsupported type %s", fieldName, field.DataType)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// graphqlRequest is a request received by newTestClient's server.
+type graphqlRequest struct {
+	Query     string                     `json:"query"`
+	Variables map[string]json.RawMessage `json:"variables"`
+}
+
+// newTestClient returns a client sending all its requests to a server
+// answering GraphQL requests with respond, which returns the data of the
+// response.
+func newTestClient(t *testing.T, respond func(req graphqlRequest) string) *ghClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, respond(req))
+	}))
+	t.Cleanup(srv.Close)
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := &http.Client{Transport: rewriteTransport{target: target}}
+	return &ghClient{Client: github.NewClient(hc), v4Client: githubql.NewClient(hc), httpClient: hc, members: map[string]bool{}}
+}
+
+// rewriteTransport sends the requests to target instead of GitHub.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = t.target.Scheme, t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// testProject returns a project with a Status field with the given options.
+func testProject(statuses ...string) *project {
+	options := map[string]githubql.String{}
+	for _, status := range statuses {
+		options[status] = githubql.String("option-" + status)
+	}
+	return &project{
+		ID:    "project",
+		Title: "SIG Auth",
+		fields: map[string]*projectField{
+			statusFieldName: {ID: "status", Name: statusFieldName, DataType: string(githubql.ProjectV2FieldTypeSingleSelect), options: options, optionNames: statuses},
+		},
+	}
+}
+
+// statusValues is the data of a field values query of an item in status.
+func statusValues(status string) string {
+	return fmt.Sprintf(`{"data": {"node": {"fieldValues": {"nodes": [{"__typename": "ProjectV2ItemFieldSingleSelectValue", "field": {"name": %q}, "name": %q}]}}}}`, statusFieldName, status)
+}
+
+func TestSetStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		current   string
+		moved     bool
+		mutations int
+		status    string
+	}{{
+		name:      "unchanged since read",
+		current:   statusNeedsTriage,
+		moved:     true,
+		mutations: 1,
+		status:    statusNeedsApprover,
+	}, {
+		name:    "moved concurrently",
+		current: statusWaitingOnAuthor,
+		status:  statusWaitingOnAuthor,
+	}, {
+		name:    "already moved concurrently",
+		current: statusNeedsApprover,
+		status:  statusNeedsApprover,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			mutations := 0
+			client := newTestClient(t, func(req graphqlRequest) string {
+				if strings.HasPrefix(req.Query, "mutation") {
+					mutations++
+					return `{"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "item"}}}}`
+				}
+				return statusValues(tc.current)
+			})
+			p := testProject(statusNeedsTriage, statusNeedsApprover, statusWaitingOnAuthor)
+			item := &projectItem{ID: "item", Status: statusNeedsTriage, values: map[string]string{statusFieldName: statusNeedsTriage}}
+
+			moved, err := client.setStatus(context.Background(), p, item, statusNeedsApprover)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if moved != tc.moved {
+				t.Errorf("setStatus() = %v, want %v", moved, tc.moved)
+			}
+			if mutations != tc.mutations {
+				t.Errorf("sent %d mutations, want %d", mutations, tc.mutations)
+			}
+			if item.Status != tc.status {
+				t.Errorf("item status is %q, want %q", item.Status, tc.status)
+			}
+		})
+	}
+}
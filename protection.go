@@ -0,0 +1,120 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// branchProtectionPolicy is the protection the default branch of subproject
+// repositories must have.
+type branchProtectionPolicy struct {
+	// RequiredReviews is the minimum number of approving reviews required.
+	RequiredReviews int `json:"requiredReviews,omitempty"`
+	// RequiredChecks are the status checks that must be required.
+	RequiredChecks []string `json:"requiredChecks,omitempty"`
+	// AllowForcePushes permits force pushes to the branch.
+	AllowForcePushes bool `json:"allowForcePushes,omitempty"`
+	// AllowDeletions permits deleting the branch.
+	AllowDeletions bool `json:"allowDeletions,omitempty"`
+}
+
+// violations returns the ways protection does not comply with the policy.
+// protection is nil for unprotected branches.
+func (p branchProtectionPolicy) violations(protection *github.Protection) []string {
+	if protection == nil {
+		return []string{"branch is not protected"}
+	}
+
+	var violations []string
+	var reviews int
+	if enforcement := protection.GetRequiredPullRequestReviews(); enforcement != nil {
+		reviews = enforcement.RequiredApprovingReviewCount
+	}
+	if reviews < p.RequiredReviews {
+		violations = append(violations, fmt.Sprintf("requires %d approving reviews, want at least %d", reviews, p.RequiredReviews))
+	}
+	required := map[string]bool{}
+	if checks := protection.GetRequiredStatusChecks(); checks != nil {
+		for _, context := range checks.Contexts {
+			required[context] = true
+		}
+		for _, check := range checks.Checks {
+			required[check.Context] = true
+		}
+	}
+	for _, check := range p.RequiredChecks {
+		if !required[check] {
+			violations = append(violations, fmt.Sprintf("does not require status check %q", check))
+		}
+	}
+	if protection.GetAllowForcePushes().Enabled && !p.AllowForcePushes {
+		violations = append(violations, "allows force pushes")
+	}
+	if protection.GetAllowDeletions().Enabled && !p.AllowDeletions {
+		violations = append(violations, "allows deletion")
+	}
+	return violations
+}
+
+// runAuditBranches checks the default branch protection of each subproject
+// repository against the config's branchProtection policy.
+func runAuditBranches(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("audit-branches", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("# Branch protection violations")
+	for _, repo := range repos {
+		protection, _, err := client.Repositories.GetBranchProtection(ctx, repo.GetOwner().GetLogin(), repo.GetName(), repo.GetDefaultBranch())
+		if errors.Is(err, github.ErrBranchNotProtected) {
+			protection, err = nil, nil
+		}
+		if err != nil {
+			return err
+		}
+
+		violations := cfg.BranchProtection.violations(protection)
+		if len(violations) == 0 {
+			continue
+		}
+		fmt.Printf("\n## %s (%s)\n\n", repo.GetFullName(), repo.GetDefaultBranch())
+		for _, v := range violations {
+			fmt.Printf("- %s\n", v)
+		}
+	}
+	return nil
+}
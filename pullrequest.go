@@ -0,0 +1,126 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// pullRequest holds the details of a PR that are only exposed by the GraphQL API.
+type pullRequest struct {
+	ReviewDecision githubql.PullRequestReviewDecision
+	// UnresolvedThreads is the number of review threads that are not resolved.
+	UnresolvedThreads int
+}
+
+func (c *ghClient) getPullRequest(ctx context.Context, nodeID string) (*pullRequest, error) {
+	var query struct {
+		Node struct {
+			PullRequest struct {
+				ReviewDecision githubql.PullRequestReviewDecision `graphql:"reviewDecision"`
+				ReviewThreads  struct {
+					Nodes []struct {
+						IsResolved githubql.Boolean `graphql:"isResolved"`
+					} `graphql:"nodes"`
+					PageInfo struct {
+						HasNextPage githubql.Boolean `graphql:"hasNextPage"`
+						EndCursor   githubql.String  `graphql:"endCursor"`
+					} `graphql:"pageInfo"`
+				} `graphql:"reviewThreads(first: $perPage, after: $cursor)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	variables := map[string]interface{}{
+		"id":      githubql.ID(nodeID),
+		"perPage": githubql.Int(perPage),
+		"cursor":  (*githubql.String)(nil),
+	}
+
+	pr := &pullRequest{}
+	for {
+		query.Node.PullRequest.ReviewThreads.Nodes = nil
+		err := c.v4Client.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, err
+		}
+		pr.ReviewDecision = query.Node.PullRequest.ReviewDecision
+		for _, thread := range query.Node.PullRequest.ReviewThreads.Nodes {
+			if !thread.IsResolved {
+				pr.UnresolvedThreads++
+			}
+		}
+		if !query.Node.PullRequest.ReviewThreads.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubql.NewString(query.Node.PullRequest.ReviewThreads.PageInfo.EndCursor)
+	}
+
+	return pr, nil
+}
+
+// listMergedPullRequests returns the merged PRs of the repository matching opts.
+// PRs are only returned if they were closed after opts.Since.
+func (c *ghClient) listMergedPullRequests(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.PullRequest, error) {
+	opts.State = "closed"
+	since := opts.Since
+	closed, err := c.listIssues(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []*github.PullRequest
+	for _, issue := range closed {
+		// Since filters on the update time, which may be later than the close time.
+		if !issue.IsPullRequest() || issue.GetClosedAt().Before(since) {
+			continue
+		}
+		// The issues API does not tell merged and closed PRs apart.
+		pr, _, err := c.PullRequests.Get(ctx, owner, repo, issue.GetNumber())
+		if err != nil {
+			return nil, err
+		}
+		if pr.GetMerged() {
+			merged = append(merged, pr)
+		}
+	}
+	return merged, nil
+}
+
+// hasOpenLinkedPullRequest reports whether the issue is linked to an open PR
+// that closes it when merged, i.e. someone is already working on a fix.
+func (c *ghClient) hasOpenLinkedPullRequest(ctx context.Context, nodeID string) (bool, error) {
+	var query struct {
+		Node struct {
+			Issue struct {
+				ClosedByPullRequestsReferences struct {
+					TotalCount githubql.Int `graphql:"totalCount"`
+				} `graphql:"closedByPullRequestsReferences(first: 1, includeClosedPrs: false)"`
+			} `graphql:"... on Issue"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubql.ID(nodeID),
+	}
+	if err := c.v4Client.Query(ctx, &query, variables); err != nil {
+		return false, err
+	}
+	return query.Node.Issue.ClosedByPullRequestsReferences.TotalCount > 0, nil
+}
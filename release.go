@@ -0,0 +1,187 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// defaultReleaseTasks are the post-release tasks when the profile sets none.
+var defaultReleaseTasks = []string{
+	"Announce the release on the SIG Auth mailing list and Slack channel",
+	"Update the documentation for the release",
+	"Bump the Helm chart to the release",
+}
+
+// releaseFollowUp configures the task list opened for each new subproject
+// release, so that the follow-ups are not forgotten.
+type releaseFollowUp struct {
+	// Tasks are the post-release tasks. Empty means defaultReleaseTasks.
+	Tasks []string `json:"tasks,omitempty"`
+	// Draft adds a draft issue to the board instead of opening an issue in the
+	// subproject repository.
+	Draft bool `json:"draft,omitempty"`
+}
+
+// runReleaseFollowUps opens a tracking issue, or a draft board item, with the
+// post-release tasks for each subproject release published within --since. It
+// is meant to run on a schedule at least as frequent as --since.
+func runReleaseFollowUps(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("release-followups", flag.ExitOnError)
+	common.register(fs)
+	since := fs.Duration("since", 24*time.Hour, "open follow-ups for releases published within this duration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	followUp := prof.ReleaseFollowUp
+	if followUp == nil {
+		followUp = &releaseFollowUp{}
+	}
+	tasks := followUp.Tasks
+	if len(tasks) == 0 {
+		tasks = defaultReleaseTasks
+	}
+
+	client := newClient(ctx, tokens)
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+	var p *project
+	drafts := map[string]bool{}
+	if followUp.Draft {
+		if p, err = client.getProject(ctx, orgName, prof.Project); err != nil {
+			return err
+		}
+		items, err := client.listProjectItems(ctx, p)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if item.Type == githubql.ProjectV2ItemTypeDraftIssue {
+				drafts[item.Title] = true
+			}
+		}
+	}
+
+	cutoff := time.Now().Add(-*since)
+	for _, repo := range repos {
+		owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+		// Releases are listed newest first, a page covers any sensible --since.
+		releases, _, err := client.Repositories.ListReleases(ctx, owner, name, &github.ListOptions{PerPage: perPage})
+		if err != nil {
+			return err
+		}
+		for _, release := range releases {
+			if release.GetDraft() || release.GetPrerelease() || release.GetPublishedAt().Before(cutoff) {
+				continue
+			}
+
+			var b strings.Builder
+			fmt.Fprintf(&b, "[%s](%s) was published on %s. Check off the follow-up tasks once done:\n\n", release.GetTagName(), release.GetHTMLURL(), release.GetPublishedAt().Format(dateFormat))
+			for _, task := range tasks {
+				fmt.Fprintf(&b, "- [ ] %s\n", task)
+			}
+			b.WriteString("\nThis issue was opened by the [SIG Auth tools](https://github.com/kubernetes-sigs/sig-auth-tools).\n")
+
+			if followUp.Draft {
+				title := fmt.Sprintf("Post-release tasks for %s %s", repo.GetFullName(), release.GetTagName())
+				if drafts[title] {
+					continue
+				}
+				fmt.Printf("adding draft %q\n", title)
+				if _, err := client.addProjectV2DraftIssue(ctx, p, title, b.String()); err != nil {
+					return err
+				}
+				continue
+			}
+
+			title := fmt.Sprintf("Post-release tasks for %s", release.GetTagName())
+			// Closed issues count too, the tasks may already be done.
+			exists, err := client.hasIssue(ctx, repo.GetFullName(), title)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+			issue, _, err := client.Issues.Create(ctx, owner, name, &github.IssueRequest{
+				Title: github.String(title),
+				Body:  github.String(b.String()),
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("created issue %s\n", issue.GetHTMLURL())
+		}
+	}
+	return nil
+}
+
+// hasIssue reports whether the repository has an issue with the exact title,
+// open or closed.
+func (c *ghClient) hasIssue(ctx context.Context, repo, title string) (bool, error) {
+	query := fmt.Sprintf("repo:%s is:issue in:title %q", repo, title)
+	result, _, err := c.Search.Issues(ctx, query, &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: perPage},
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, issue := range result.Issues {
+		if issue.GetTitle() == title {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// addProjectV2DraftIssue adds a draft issue to the project and returns the
+// resulting item.
+func (c *ghClient) addProjectV2DraftIssue(ctx context.Context, p *project, title, body string) (*projectItem, error) {
+	var mutation struct {
+		AddProjectV2DraftIssue struct {
+			ProjectItem struct {
+				ID githubql.ID `graphql:"id"`
+			} `graphql:"projectItem"`
+		} `graphql:"addProjectV2DraftIssue(input: $input)"`
+	}
+	input := githubql.AddProjectV2DraftIssueInput{
+		ProjectID: p.ID,
+		Title:     githubql.String(title),
+		Body:      githubql.NewString(githubql.String(body)),
+	}
+	if err := c.v4Client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return nil, err
+	}
+	item := &projectItem{ID: mutation.AddProjectV2DraftIssue.ProjectItem.ID, Type: githubql.ProjectV2ItemTypeDraftIssue, Title: title}
+	c.recordMutation(auditEntry{Action: auditAdd, Project: p.Title, ItemID: fmt.Sprint(item.ID), Content: title})
+	return item, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// requiredRepoFiles are the files and directories every subproject repository
+// must have.
+var requiredRepoFiles = []string{
+	".github/ISSUE_TEMPLATE",
+	"SECURITY.md",
+	"SECURITY_CONTACTS",
+}
+
+// runAuditRepos checks that each subproject repository has a description, the
+// topics of its source, the required files and the standard Kubernetes labels.
+func runAuditRepos(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("audit-repos", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	standard, err := client.listStandardLabels(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("# Subproject repository compliance")
+	for _, src := range prof.Sources {
+		if len(src.Topics) == 0 {
+			continue
+		}
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			problems, err := client.auditRepo(ctx, src, repo, standard)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("\n## %s\n\n", repo.GetFullName())
+			if len(problems) == 0 {
+				fmt.Println("- compliant")
+			}
+			for _, problem := range problems {
+				fmt.Printf("- %s\n", problem)
+			}
+		}
+	}
+	return nil
+}
+
+// auditRepo returns the compliance problems of the repository.
+func (c *ghClient) auditRepo(ctx context.Context, src source, repo *github.Repository, standard []labelSpec) ([]string, error) {
+	var problems []string
+	if strings.TrimSpace(repo.GetDescription()) == "" {
+		problems = append(problems, "no description")
+	}
+	topics := stringSet(repo.Topics)
+	for _, topic := range src.Topics {
+		if !topics[topic] {
+			problems = append(problems, fmt.Sprintf("missing topic %q", topic))
+		}
+	}
+
+	owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+	for _, path := range requiredRepoFiles {
+		_, _, resp, err := c.Repositories.GetContents(ctx, owner, name, path, nil)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			problems = append(problems, fmt.Sprintf("missing %s", path))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	labels, err := c.listLabels(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, label := range standard {
+		if _, ok := labels[label.Name]; !ok {
+			missing = append(missing, label.Name)
+		}
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing labels: %s", strings.Join(missing, ", ")))
+	}
+	return problems, nil
+}
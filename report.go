@@ -0,0 +1,63 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// reports are the reports available through the report command, by name.
+var reports = map[string]func(ctx context.Context, tokens, args []string) error{
+	"activity":         runActivityReport,
+	"analytics":        runAnalyticsReport,
+	"approval-latency": runApprovalLatencyReport,
+	"auth-changes":     runAuthChangesReport,
+	"emeritus":         runEmeritusReport,
+	"feature-gates":    runFeatureGatesReport,
+	"freeze":           runFreezeReport,
+	"missing-docs":     runMissingDocsReport,
+	"orphan-prs":       runOrphanPRsReport,
+	"release-notes":    runReleaseNotesReport,
+	"rotted":           runRottedReport,
+	"stale-releases":   runStaleReleasesReport,
+	"tide":             runTideReport,
+	"untriaged":        runUntriagedReport,
+}
+
+func runReport(ctx context.Context, tokens, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing report name, must be one of: %s", strings.Join(reportNames(), ", "))
+	}
+
+	run, ok := reports[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown report %q, must be one of: %s", args[0], strings.Join(reportNames(), ", "))
+	}
+	return run(ctx, tokens, args[1:])
+}
+
+func reportNames() []string {
+	var names []string
+	for name := range reports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// subprojectActivity is the contribution activity of a subproject in a window.
+type subprojectActivity struct {
+	Commits      int
+	Contributors loginSet
+	// NewContributors are the contributors whose first commit is in the window.
+	NewContributors loginSet
+	Reviews         int
+	Reviewers       loginSet
+}
+
+// runActivityReport summarizes the contributors and review activity of each
+// subproject, so leads can spot subprojects trending toward unmaintained.
+func runActivityReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report activity", flag.ExitOnError)
+	common.register(fs)
+	days := fs.Int("days", 90, "report the activity of this many days")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().AddDate(0, 0, -*days)
+	bots := stringSet(cfg.BotAuthors)
+	fmt.Printf("# Subproject activity in the last %d days\n\n", *days)
+	fmt.Println("| Subproject | Commits | Contributors | New contributors | Reviews | Reviewers |")
+	fmt.Println("| --- | --- | --- | --- | --- | --- |")
+	for _, repo := range repos {
+		a, err := client.getSubprojectActivity(ctx, repo.GetOwner().GetLogin(), repo.GetName(), since, bots)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("| %s | %d | %d | %d | %d | %d |\n", repo.GetFullName(), a.Commits, len(a.Contributors), len(a.NewContributors), a.Reviews, len(a.Reviewers))
+	}
+	return nil
+}
+
+func (c *ghClient) getSubprojectActivity(ctx context.Context, owner, repo string, since time.Time, bots map[string]bool) (*subprojectActivity, error) {
+	a := &subprojectActivity{Contributors: loginSet{}, NewContributors: loginSet{}, Reviewers: loginSet{}}
+
+	commitOpts := &github.CommitsListOptions{Since: since, ListOptions: github.ListOptions{PerPage: perPage}}
+	for {
+		commits, resp, err := c.Repositories.ListCommits(ctx, owner, repo, commitOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			a.Commits++
+			// Commits by authors without a GitHub account have no login.
+			if login := commit.GetAuthor().GetLogin(); login != "" && !bots[login] {
+				a.Contributors.add(login)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		commitOpts.Page = resp.NextPage
+	}
+
+	for login := range a.Contributors {
+		earlier, _, err := c.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+			Author:      login,
+			Until:       since,
+			ListOptions: github.ListOptions{PerPage: 1},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(earlier) == 0 {
+			a.NewContributors.add(login)
+		}
+	}
+
+	prOpts := &github.PullRequestListOptions{
+		State:       "all",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: perPage},
+	}
+	for {
+		prs, resp, err := c.PullRequests.List(ctx, owner, repo, prOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			// PRs are sorted by update time, and reviews update the PR.
+			if pr.GetUpdatedAt().Before(since) {
+				return a, nil
+			}
+			if err := c.countReviews(ctx, owner, repo, pr.GetNumber(), since, bots, a); err != nil {
+				return nil, err
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		prOpts.Page = resp.NextPage
+	}
+	return a, nil
+}
+
+func (c *ghClient) countReviews(ctx context.Context, owner, repo string, number int, since time.Time, bots map[string]bool, a *subprojectActivity) error {
+	opts := &github.ListOptions{PerPage: perPage}
+	for {
+		reviews, resp, err := c.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		if err != nil {
+			return err
+		}
+		for _, review := range reviews {
+			login := review.GetUser().GetLogin()
+			if review.GetSubmittedAt().Before(since) || bots[login] {
+				continue
+			}
+			a.Reviews++
+			a.Reviewers.add(login)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// itemHistory is the life of a board item as recorded by the snapshots. Times
+// are those of the first snapshot showing the change, so they are only as
+// precise as the sync schedule.
+type itemHistory struct {
+	Item      snapshotItem
+	Triaged   time.Time
+	Closed    time.Time
+	Merged    bool
+	Responded time.Time
+}
+
+// quarterStats are the durations measured for the items created in a quarter.
+type quarterStats struct {
+	Items         int
+	FirstResponse []time.Duration
+	Triage        []time.Duration
+	Merge         []time.Duration
+	Close         []time.Duration
+}
+
+// runAnalyticsReport prints the median time to first response, triage, merge
+// and close of the board items per quarter they were created in, computed from
+// the snapshots written by sync.
+func runAnalyticsReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report analytics", flag.ExitOnError)
+	common.register(fs)
+	snapshotDir := fs.String("snapshot-dir", "", "directory holding the board snapshots written by sync")
+	firstResponse := fs.Bool("first-response", false, "also measure the time to first response, which reads the comments of every item")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *snapshotDir == "" {
+		return fmt.Errorf("--snapshot-dir is required")
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	snaps, err := loadSnapshots(*snapshotDir)
+	if err != nil {
+		return err
+	}
+	histories := itemHistories(prof, snaps)
+
+	if *firstResponse {
+		client := newClient(ctx, tokens)
+		bots := stringSet(cfg.BotAuthors)
+		for _, h := range histories {
+			if h.Item.URL == "" {
+				continue
+			}
+			h.Responded, err = client.firstResponse(ctx, h.Item, bots)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	quarters := map[string]*quarterStats{}
+	for _, h := range histories {
+		if h.Item.CreatedAt == nil {
+			continue
+		}
+		created := *h.Item.CreatedAt
+		q := fmt.Sprintf("%d-Q%d", created.Year(), (int(created.Month())+2)/3)
+		stats, ok := quarters[q]
+		if !ok {
+			stats = &quarterStats{}
+			quarters[q] = stats
+		}
+		stats.Items++
+		if !h.Responded.IsZero() {
+			stats.FirstResponse = append(stats.FirstResponse, h.Responded.Sub(created))
+		}
+		if !h.Triaged.IsZero() {
+			stats.Triage = append(stats.Triage, h.Triaged.Sub(created))
+		}
+		switch {
+		case h.Closed.IsZero():
+		case h.Merged:
+			stats.Merge = append(stats.Merge, h.Closed.Sub(created))
+		default:
+			stats.Close = append(stats.Close, h.Closed.Sub(created))
+		}
+	}
+
+	names := make([]string, 0, len(quarters))
+	for q := range quarters {
+		names = append(names, q)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("# %s response times\n\n", prof.Project)
+	fmt.Println("| Quarter | Items | First response | Triage | Merge | Close |")
+	fmt.Println("| --- | --- | --- | --- | --- | --- |")
+	for _, q := range names {
+		s := quarters[q]
+		fmt.Printf("| %s | %d | %s | %s | %s | %s |\n", q, s.Items, formatMedian(s.FirstResponse), formatMedian(s.Triage), formatMedian(s.Merge), formatMedian(s.Close))
+	}
+	return nil
+}
+
+// itemHistories replays the snapshots, oldest first, and returns the history
+// of each item.
+func itemHistories(prof profile, snaps []*boardSnapshot) map[string]*itemHistory {
+	histories := map[string]*itemHistory{}
+	for _, snap := range snaps {
+		for _, item := range snap.Items {
+			h, ok := histories[item.ID]
+			if !ok {
+				h = &itemHistory{}
+				histories[item.ID] = h
+			}
+			h.Item = item
+			if h.Triaged.IsZero() && !prof.isUntriaged(item.Status) {
+				h.Triaged = snap.Time
+			}
+			if h.Closed.IsZero() && item.State != "" && item.State != "OPEN" {
+				h.Closed, h.Merged = snap.Time, item.State == "MERGED"
+			}
+		}
+	}
+	return histories
+}
+
+// firstResponse returns the time of the first comment on the item by someone
+// other than its author and the bots, or the zero time if there is none.
+func (c *ghClient) firstResponse(ctx context.Context, item snapshotItem, bots map[string]bool) (time.Time, error) {
+	owner, repo, err := splitRepo(item.Repository)
+	if err != nil {
+		return time.Time{}, err
+	}
+	issue, _, err := c.Issues.Get(ctx, owner, repo, item.Number)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	opts := &github.IssueListCommentsOptions{
+		Sort:        github.String("created"),
+		Direction:   github.String("asc"),
+		ListOptions: github.ListOptions{PerPage: perPage},
+	}
+	for {
+		comments, resp, err := c.Issues.ListComments(ctx, owner, repo, item.Number, opts)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, comment := range comments {
+			login := comment.GetUser().GetLogin()
+			if login != issue.GetUser().GetLogin() && !bots[login] {
+				return comment.GetCreatedAt(), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return time.Time{}, nil
+}
+
+// formatMedian returns the median duration in days, or n/a if there are none.
+func formatMedian(durations []time.Duration) string {
+	if len(durations) == 0 {
+		return "n/a"
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	median := durations[len(durations)/2]
+	if len(durations)%2 == 0 {
+		median = (durations[len(durations)/2-1] + median) / 2
+	}
+	return fmt.Sprintf("%.1fd", median.Hours()/24)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// runApprovalLatencyReport prints the median time from the first review
+// request, or lgtm, to the approval of the PRs of the profile's sources, per
+// repository and quarter of the approval, so leads can tell where approver
+// coverage is thin.
+func runApprovalLatencyReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report approval-latency", flag.ExitOnError)
+	common.register(fs)
+	months := fs.Int("months", 12, "measure the PRs updated in the last this many months")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	bots := stringSet(cfg.BotAuthors)
+	since := time.Now().AddDate(0, -*months, 0)
+
+	client := newClient(ctx, tokens)
+	// Latencies by repository, then by quarter.
+	latencies := map[string]map[string][]time.Duration{}
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			owner := repo.GetOwner().GetLogin()
+			items, err := client.listIssues(ctx, owner, *repo.Name, &github.IssueListByRepoOptions{
+				State:  "all",
+				Labels: src.Labels,
+				Since:  since,
+			})
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if !item.IsPullRequest() || bots[item.GetUser().GetLogin()] {
+					continue
+				}
+				requested, approved, err := client.approvalTimes(ctx, owner, *repo.Name, item.GetNumber())
+				if err != nil {
+					return err
+				}
+				if requested.IsZero() || approved.IsZero() || approved.Before(since) {
+					continue
+				}
+				byQuarter, ok := latencies[repo.GetFullName()]
+				if !ok {
+					byQuarter = map[string][]time.Duration{}
+					latencies[repo.GetFullName()] = byQuarter
+				}
+				q := fmt.Sprintf("%d-Q%d", approved.Year(), (int(approved.Month())+2)/3)
+				byQuarter[q] = append(byQuarter[q], approved.Sub(requested))
+			}
+		}
+	}
+
+	repoNames := make([]string, 0, len(latencies))
+	for name := range latencies {
+		repoNames = append(repoNames, name)
+	}
+	sort.Strings(repoNames)
+
+	fmt.Printf("# %s approval latency\n\n", prof.Project)
+	fmt.Println("| Repository | Quarter | Approved PRs | Median time to approval |")
+	fmt.Println("| --- | --- | --- | --- |")
+	for _, name := range repoNames {
+		quarters := make([]string, 0, len(latencies[name]))
+		for q := range latencies[name] {
+			quarters = append(quarters, q)
+		}
+		sort.Strings(quarters)
+		for _, q := range quarters {
+			durations := latencies[name][q]
+			fmt.Printf("| %s | %s | %d | %s |\n", name, q, len(durations), formatMedian(durations))
+		}
+	}
+	return nil
+}
+
+// approvalTimes returns the time the PR was first ready for an approver, i.e.
+// of its first review request or lgtm, and the time it was first approved
+// after that, through the approved label or an approving review. Times are zero
+// when the events did not happen.
+func (c *ghClient) approvalTimes(ctx context.Context, owner, repo string, number int) (requested, approved time.Time, err error) {
+	opts := &github.ListOptions{PerPage: perPage}
+	for {
+		events, resp, err := c.Issues.ListIssueTimeline(ctx, owner, repo, number, opts)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		for _, event := range events {
+			var at time.Time
+			if event.CreatedAt != nil {
+				at = *event.CreatedAt
+			}
+			switch {
+			case requested.IsZero() && (event.GetEvent() == "review_requested" || event.GetEvent() == "labeled" && event.GetLabel().GetName() == "lgtm"):
+				requested = at
+			case requested.IsZero():
+			case event.GetEvent() == "labeled" && event.GetLabel().GetName() == "approved":
+				return requested, at, nil
+			case event.GetEvent() == "reviewed" && event.GetState() == "approved" && event.SubmittedAt != nil:
+				return requested, *event.SubmittedAt, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return requested, time.Time{}, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// authRelevantRE matches release notes mentioning authentication,
+// authorization or certificates.
+var authRelevantRE = regexp.MustCompile(`(?i)\b(authn|authz|authenticat\w*|authoriz\w*|certificates?|x509|rbac|oidc|service ?account tokens?)\b`)
+
+// runAuthChangesReport lists the PRs of other SIGs merged in the milestone of
+// kubernetes/kubernetes whose release note mentions authentication,
+// authorization or certificates, so the SIG can review their impact before the
+// release.
+func runAuthChangesReport(ctx context.Context, tokens, args []string) error {
+	fs := flag.NewFlagSet("report auth-changes", flag.ExitOnError)
+	milestone := fs.String("milestone", "", "milestone to scan, e.g. v1.34. Defaults to the release in progress")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	var err error
+	if *milestone == "" {
+		*milestone, err = client.currentMilestone(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := fmt.Sprintf("repo:%s/kubernetes is:pr is:merged milestone:%q -label:sig/auth", orgName, *milestone)
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: perPage}}
+	fmt.Printf("# PRs of other SIGs merged in %s with auth-relevant release notes\n\n", *milestone)
+	for {
+		// The search API returns at most 1000 results, about the size of a
+		// release milestone.
+		result, resp, err := client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return err
+		}
+		for _, pr := range result.Issues {
+			m := releaseNoteRE.FindStringSubmatch(pr.GetBody())
+			if m == nil || !authRelevantRE.MatchString(m[1]) {
+				continue
+			}
+			var sigs []string
+			for _, label := range pr.Labels {
+				if strings.HasPrefix(label.GetName(), "sig/") {
+					sigs = append(sigs, label.GetName())
+				}
+			}
+			fmt.Printf("- %s %s (%s)\n", pr.GetHTMLURL(), pr.GetTitle(), strings.Join(sigs, ", "))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return nil
+}
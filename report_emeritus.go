@@ -0,0 +1,146 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// ownersFile is the part of an OWNERS file listing people. Entries may also be
+// aliases defined in OWNERS_ALIASES.
+type ownersFile struct {
+	Approvers []string `json:"approvers"`
+	Reviewers []string `json:"reviewers"`
+}
+
+// runEmeritusReport lists the SIG Auth approvers and reviewers without review
+// or comment activity in the last months, with a link to their last activity.
+func runEmeritusReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report emeritus", flag.ExitOnError)
+	common.register(fs)
+	months := fs.Int("months", 12, "list people without activity for this many months")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	var aliases ownersAliasesFile
+	if err := client.getYAMLFile(ctx, orgName, "kubernetes", "OWNERS_ALIASES", &aliases); err != nil {
+		return err
+	}
+
+	// listedIn maps each person to the places they are listed in.
+	listedIn := map[string][]string{}
+	for name, members := range aliases.Aliases {
+		if !strings.HasPrefix(name, sigDir+"-") {
+			continue
+		}
+		for _, login := range members {
+			listedIn[strings.ToLower(login)] = append(listedIn[strings.ToLower(login)], "OWNERS_ALIASES "+name)
+		}
+	}
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		var owners ownersFile
+		err := client.getYAMLFile(ctx, repo.GetOwner().GetLogin(), repo.GetName(), "OWNERS", &owners)
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, login := range append(owners.Approvers, owners.Reviewers...) {
+			// Aliases are covered by OWNERS_ALIASES.
+			if _, ok := aliases.Aliases[login]; ok {
+				continue
+			}
+			listedIn[strings.ToLower(login)] = append(listedIn[strings.ToLower(login)], repo.GetFullName()+" OWNERS")
+		}
+	}
+
+	var orgs []string
+	for _, src := range prof.Sources {
+		if src.Org != "" {
+			orgs = append(orgs, "org:"+src.Org)
+		}
+		for _, repo := range src.Repos {
+			orgs = append(orgs, "repo:"+repo)
+		}
+	}
+	scope := strings.Join(orgs, " ")
+	since := time.Now().AddDate(0, -*months, 0).Format(dateFormat)
+
+	logins := make([]string, 0, len(listedIn))
+	for login := range listedIn {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+
+	fmt.Printf("# Emeritus candidates without activity since %s\n", since)
+	for _, login := range logins {
+		active := false
+		for _, qualifier := range []string{"reviewed-by", "commenter"} {
+			result, _, err := client.Search.Issues(ctx, fmt.Sprintf("%s %s:%s updated:>=%s", scope, qualifier, login, since), &github.SearchOptions{
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			if err != nil {
+				return err
+			}
+			if result.GetTotal() > 0 {
+				active = true
+				break
+			}
+		}
+		if active {
+			continue
+		}
+
+		last, _, err := client.Search.Issues(ctx, fmt.Sprintf("%s commenter:%s", scope, login), &github.SearchOptions{
+			Sort:        "updated",
+			Order:       "desc",
+			ListOptions: github.ListOptions{PerPage: 1},
+		})
+		if err != nil {
+			return err
+		}
+		lastActivity := "no activity found"
+		if len(last.Issues) > 0 {
+			lastActivity = "last activity: " + last.Issues[0].GetHTMLURL()
+		}
+		fmt.Printf("\n- @%s, %s\n  - listed in %s\n", login, lastActivity, strings.Join(listedIn[login], ", "))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// runFeatureGatesReport lists the feature gates belonging to SIG Auth KEPs with
+// the release each of their stages started in, flagging gates that have been in
+// the same stage for too long.
+func runFeatureGatesReport(ctx context.Context, tokens, args []string) error {
+	fs := flag.NewFlagSet("report feature-gates", flag.ExitOnError)
+	ref := fs.String("ref", "master", "kubernetes/kubernetes branch or tag to read the feature gates from")
+	maxReleases := fs.Int("max-releases", 3, "flag gates that have been in the same stage for at least this many releases")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	keps, err := client.listSIGKEPs(ctx, "sig-auth")
+	if err != nil {
+		return err
+	}
+	gates, err := client.listFeatureGates(ctx, *ref)
+	if err != nil {
+		return err
+	}
+	milestone, err := client.currentMilestone(ctx)
+	if err != nil {
+		return err
+	}
+	currentMinor := parseMinor(milestone)
+
+	fmt.Printf("# SIG Auth feature gates at %s\n\n", *ref)
+	fmt.Println("| Gate | KEP | Stages | Overdue |")
+	fmt.Println("| --- | --- | --- | --- |")
+	for _, gate := range gates {
+		if !keps[gate.KEP] {
+			continue
+		}
+		var stages []string
+		for _, stage := range gate.Stages {
+			if stage.Minor == 0 {
+				stages = append(stages, stage.PreRelease)
+				continue
+			}
+			stages = append(stages, fmt.Sprintf("%s in v1.%d", stage.PreRelease, stage.Minor))
+		}
+		fmt.Printf("| %s | [%d](https://kep.k8s.io/%d) | %s | %s |\n", gate.Name, gate.KEP, gate.KEP, strings.Join(stages, ", "), overdue(gate, currentMinor, *maxReleases))
+	}
+	return nil
+}
+
+// overdue describes what a gate that has been in its stage for at least
+// maxReleases is overdue for, or returns an empty string.
+func overdue(gate *featureGate, currentMinor, maxReleases int) string {
+	stage := gate.current()
+	if stage.Minor == 0 || currentMinor-stage.Minor < maxReleases {
+		return ""
+	}
+	switch stage.PreRelease {
+	case "Alpha", "Beta":
+		return fmt.Sprintf("promotion, %s since v1.%d", strings.ToLower(stage.PreRelease), stage.Minor)
+	case "GA", "Deprecated":
+		return fmt.Sprintf("removal, %s since v1.%d", strings.ToLower(stage.PreRelease), stage.Minor)
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// runFreezeReport lists the open PRs targeting the release in progress when one
+// of its freezes is approaching, so they can land in time or be moved out. PRs
+// snoozed on the board are left out.
+func runFreezeReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report freeze", flag.ExitOnError)
+	common.register(fs)
+	days := fs.Int("days", 7, "alert on freezes within this many days")
+	webhook := fs.String("webhook", os.Getenv("FREEZE_WEBHOOK_URL"), "also post the report to this Slack incoming webhook, defaults to $FREEZE_WEBHOOK_URL")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	if cfg.ReleaseSchedule == nil {
+		return fmt.Errorf("no releaseSchedule configured")
+	}
+	schedule := cfg.ReleaseSchedule
+	upcoming, err := schedule.upcoming(time.Now(), time.Duration(*days)*24*time.Hour)
+	if err != nil {
+		return err
+	}
+	if len(upcoming) == 0 {
+		fmt.Printf("No %s freeze within %d days\n", schedule.Release, *days)
+		return nil
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	boardItems, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	snoozedURLs := map[string]bool{}
+	for _, item := range boardItems {
+		if _, active := snoozed(item.values, now); active {
+			snoozedURLs[item.URL] = true
+		}
+	}
+
+	var open []*github.Issue
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			m, err := client.findMilestone(ctx, repo.GetOwner().GetLogin(), *repo.Name, schedule.Release)
+			if err != nil {
+				return err
+			}
+			if m == nil || m.GetState() != "open" {
+				continue
+			}
+			items, err := client.listIssues(ctx, repo.GetOwner().GetLogin(), *repo.Name, &github.IssueListByRepoOptions{
+				Milestone: strconv.Itoa(m.GetNumber()),
+				Labels:    src.Labels,
+			})
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if item.IsPullRequest() && !snoozedURLs[item.GetHTMLURL()] {
+					open = append(open, item)
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s freeze alerts\n\n", schedule.Release)
+	today := now.UTC().Truncate(24 * time.Hour)
+	for _, f := range upcoming {
+		fmt.Fprintf(&b, "- %s is %s (%s)\n", f.Name, formatDaysUntil(int(f.Date.Sub(today).Hours()/24)), f.Date.Format(dateFormat))
+	}
+	fmt.Fprintf(&b, "\n## Open PRs targeting %s (%d)\n\n", schedule.Release, len(open))
+	for _, pr := range open {
+		fmt.Fprintf(&b, "- %s %s\n", pr.GetHTMLURL(), pr.GetTitle())
+	}
+	fmt.Print(b.String())
+
+	if *webhook == "" {
+		return nil
+	}
+	return postWebhook(ctx, *webhook, map[string]string{"text": b.String()})
+}
+
+func formatDaysUntil(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	}
+	return fmt.Sprintf("in %d days", days)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// docsRepo is the repository holding the Kubernetes documentation.
+const docsRepo = "kubernetes/website"
+
+// docsLabels are the labels marking a PR whose documentation is tracked.
+var docsLabels = []string{
+	"docs-required",
+	"tracked-in-website",
+}
+
+// docsLinks are references in a PR description that point to its documentation.
+var docsLinks = []string{
+	"github.com/" + docsRepo + "/",
+	"kubernetes.io/docs/",
+}
+
+// runMissingDocsReport lists recently merged feature PRs that have no docs
+// follow-up, so features don't ship undocumented.
+func runMissingDocsReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report missing-docs", flag.ExitOnError)
+	common.register(fs)
+	days := fs.Int("days", 30, "only report PRs merged within this many days")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	since := time.Now().AddDate(0, 0, -*days)
+	var missing []*github.PullRequest
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) || repo.GetFullName() == docsRepo {
+				continue
+			}
+			merged, err := client.listMergedPullRequests(ctx, repo.GetOwner().GetLogin(), *repo.Name, &github.IssueListByRepoOptions{
+				Labels: append([]string{"kind/feature"}, src.Labels...),
+				Since:  since,
+			})
+			if err != nil {
+				return err
+			}
+			for _, pr := range merged {
+				documented, err := client.hasDocsFollowUp(ctx, pr)
+				if err != nil {
+					return err
+				}
+				if !documented {
+					missing = append(missing, pr)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("# Feature PRs merged in the last %d days without docs\n\n", *days)
+	for _, pr := range missing {
+		fmt.Printf("- %s %s\n", pr.GetHTMLURL(), pr.GetTitle())
+	}
+	return nil
+}
+
+// hasDocsFollowUp reports whether the PR carries a docs label or links to its
+// documentation, see tracksDocs, or an issue or PR in the docs repository
+// references it.
+func (c *ghClient) hasDocsFollowUp(ctx context.Context, pr *github.PullRequest) (bool, error) {
+	if tracksDocs(pr) {
+		return true, nil
+	}
+
+	query := fmt.Sprintf("repo:%s %q", docsRepo, pr.GetHTMLURL())
+	result, _, err := c.Search.Issues(ctx, query, &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.GetTotal() > 0, nil
+}
+
+// tracksDocs reports whether the PR carries one of the docsLabels or its
+// description links to its documentation, which needs no search.
+func tracksDocs(pr *github.PullRequest) bool {
+	for _, label := range pr.Labels {
+		for _, docsLabel := range docsLabels {
+			if label.GetName() == docsLabel {
+				return true
+			}
+		}
+	}
+	for _, link := range docsLinks {
+		if strings.Contains(pr.GetBody(), link) {
+			return true
+		}
+	}
+	return false
+}
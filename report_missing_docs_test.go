@@ -0,0 +1,62 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+)
+
+func TestTracksDocs(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		labels []string
+		body   string
+		want   bool
+	}{{
+		name:   "docs required",
+		labels: []string{"kind/feature", "docs-required"},
+		want:   true,
+	}, {
+		name:   "tracked in website",
+		labels: []string{"tracked-in-website"},
+		want:   true,
+	}, {
+		name: "website PR link",
+		body: "Docs: https://github.com/kubernetes/website/pull/41000",
+		want: true,
+	}, {
+		name: "docs page link",
+		body: "See https://kubernetes.io/docs/reference/access-authn-authz/authentication/",
+		want: true,
+	}, {
+		name:   "no signal",
+		labels: []string{"kind/feature", "sig/auth"},
+		body:   "Adds structured authentication config.",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &github.PullRequest{Body: github.String(tc.body)}
+			for _, label := range tc.labels {
+				pr.Labels = append(pr.Labels, &github.Label{Name: github.String(label)})
+			}
+			if got := tracksDocs(pr); got != tc.want {
+				t.Errorf("tracksDocs() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"regexp"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// issueReferenceRE matches references to issues in a PR body: #123,
+// owner/repo#123, issue URLs and KEP numbers.
+var issueReferenceRE = regexp.MustCompile(`(?i)(^|[\s(])([\w.-]+/[\w.-]+)?#\d+\b|github\.com/[\w.-]+/[\w.-]+/issues/\d+|\bKEP-\d+\b`)
+
+// runOrphanPRsReport lists open PRs that neither link nor reference an issue,
+// which often means a feature was implemented without design discussion.
+func runOrphanPRsReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report orphan-prs", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	bots := stringSet(cfg.BotAuthors)
+
+	client := newClient(ctx, tokens)
+	var orphans []*github.Issue
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			items, err := client.listIssuesAndPullRequests(ctx, repo.GetOwner().GetLogin(), *repo.Name, src.Labels...)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				// The PR template mentions issue references in its instructions.
+				body := htmlCommentRE.ReplaceAllString(item.GetBody(), "")
+				if !item.IsPullRequest() || bots[item.GetUser().GetLogin()] || issueReferenceRE.MatchString(body) {
+					continue
+				}
+				// Issues can also be linked from the PR sidebar.
+				linked, err := client.hasLinkedIssue(ctx, item.GetNodeID())
+				if err != nil {
+					return err
+				}
+				if !linked {
+					orphans = append(orphans, item)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("# Open PRs without a linked issue\n")
+	printIssueList("Orphan PRs", orphans)
+	return nil
+}
+
+// hasLinkedIssue reports whether the PR is linked to an issue it closes.
+func (c *ghClient) hasLinkedIssue(ctx context.Context, nodeID string) (bool, error) {
+	var query struct {
+		Node struct {
+			PullRequest struct {
+				ClosingIssuesReferences struct {
+					TotalCount githubql.Int `graphql:"totalCount"`
+				} `graphql:"closingIssuesReferences(first: 1)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubql.ID(nodeID),
+	}
+	if err := c.v4Client.Query(ctx, &query, variables); err != nil {
+		return false, err
+	}
+	return query.Node.PullRequest.ClosingIssuesReferences.TotalCount > 0, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// releaseNoteRE matches the release-note block of the Kubernetes PR template.
+var releaseNoteRE = regexp.MustCompile("(?s)```release-note\\s*\\n(.*?)```")
+
+// userFacingKinds are the kind/* labels of changes that always need a release note.
+var userFacingKinds = []string{
+	"kind/api-change",
+	"kind/bug",
+	"kind/deprecation",
+	"kind/feature",
+	"kind/regression",
+}
+
+// userFacingPaths are the path prefixes of kubernetes/kubernetes whose changes
+// are visible to users.
+var userFacingPaths = []string{
+	"cmd/",
+	"pkg/apis/",
+	"plugin/",
+	"staging/src/k8s.io/api/",
+	"staging/src/k8s.io/apiserver/pkg/apis/",
+	"staging/src/k8s.io/kubectl/",
+}
+
+// runReleaseNotesReport lists user-facing PRs merged in the milestone whose
+// release note is missing or NONE, so they can be fixed before the release
+// notes draft freezes.
+func runReleaseNotesReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report release-notes", flag.ExitOnError)
+	common.register(fs)
+	milestone := fs.String("milestone", "", "milestone to audit, e.g. v1.34. Defaults to the release in progress")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	if *milestone == "" {
+		*milestone, err = client.currentMilestone(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	var missing []*github.PullRequest
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			m, err := client.findMilestone(ctx, repo.GetOwner().GetLogin(), *repo.Name, *milestone)
+			if err != nil {
+				return err
+			}
+			if m == nil {
+				continue
+			}
+			merged, err := client.listMergedPullRequests(ctx, repo.GetOwner().GetLogin(), *repo.Name, &github.IssueListByRepoOptions{
+				Milestone: strconv.Itoa(m.GetNumber()),
+				Labels:    src.Labels,
+			})
+			if err != nil {
+				return err
+			}
+			for _, pr := range merged {
+				if hasReleaseNote(pr) {
+					continue
+				}
+				userFacing, err := client.isUserFacing(ctx, repo.GetOwner().GetLogin(), *repo.Name, pr)
+				if err != nil {
+					return err
+				}
+				if userFacing {
+					missing = append(missing, pr)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("# User-facing PRs merged in %s without a release note\n\n", *milestone)
+	for _, pr := range missing {
+		fmt.Printf("- %s %s\n", pr.GetHTMLURL(), pr.GetTitle())
+	}
+	return nil
+}
+
+// hasReleaseNote reports whether the PR description has a release note other
+// than NONE.
+func hasReleaseNote(pr *github.PullRequest) bool {
+	m := releaseNoteRE.FindStringSubmatch(pr.GetBody())
+	if m == nil {
+		return false
+	}
+	note := strings.TrimSpace(m[1])
+	return note != "" && !strings.EqualFold(note, "none")
+}
+
+// isUserFacing reports whether the PR carries a user-facing kind or changes
+// user-facing paths.
+func (c *ghClient) isUserFacing(ctx context.Context, owner, repo string, pr *github.PullRequest) (bool, error) {
+	for _, label := range pr.Labels {
+		for _, kind := range userFacingKinds {
+			if label.GetName() == kind {
+				return true, nil
+			}
+		}
+	}
+
+	return c.changesPaths(ctx, owner, repo, pr.GetNumber(), userFacingPaths)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// runRottedReport lists items closed by the lifecycle bot that were either never
+// triaged or accepted by the SIG, so they can be reopened deliberately.
+func runRottedReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report rotted", flag.ExitOnError)
+	common.register(fs)
+	days := fs.Int("days", 30, "only report items closed within this many days")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+	board := itemsByContentID(items)
+
+	since := time.Now().AddDate(0, 0, -*days)
+	var untriaged, accepted []*github.Issue
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			// The lifecycle bot only closes items once they are rotten, and the
+			// label is kept after closing.
+			closed, err := client.listIssues(ctx, repo.GetOwner().GetLogin(), *repo.Name, &github.IssueListByRepoOptions{
+				State:  "closed",
+				Labels: append([]string{"lifecycle/rotten"}, src.Labels...),
+				Since:  since,
+			})
+			if err != nil {
+				return err
+			}
+			for _, issue := range closed {
+				if issue.GetClosedAt().Before(since) {
+					continue
+				}
+				var status string
+				if item, ok := board[issue.GetNodeID()]; ok {
+					status = item.Status
+				}
+				switch {
+				case status == statusAccepted:
+					accepted = append(accepted, issue)
+				case prof.isReconcilable(status):
+					untriaged = append(untriaged, issue)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("# Items closed as rotten in the last %d days\n", *days)
+	printIssueList("Never triaged", untriaged)
+	printIssueList("Accepted", accepted)
+	return nil
+}
+
+func printIssueList(title string, issues []*github.Issue) {
+	fmt.Printf("\n## %s (%d)\n\n", title, len(issues))
+	for _, issue := range issues {
+		fmt.Printf("- %s %s\n", issue.GetHTMLURL(), issue.GetTitle())
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// staleReleaseIssueTitle is the title of the reminder issue opened in
+// subprojects that are due for a release.
+const staleReleaseIssueTitle = "Cut a new release"
+
+// runStaleReleasesReport lists subprojects with many commits since their latest
+// release, or no release for a long time, and optionally opens a reminder issue
+// in each of them.
+func runStaleReleasesReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report stale-releases", flag.ExitOnError)
+	common.register(fs)
+	months := fs.Int("months", 6, "flag subprojects without a release for this many months")
+	minCommits := fs.Int("min-commits", 20, "flag subprojects with at least this many unreleased commits")
+	openIssues := fs.Bool("open-issues", false, "open or update a reminder issue in each flagged subproject")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().AddDate(0, -*months, 0)
+	fmt.Println("# Subprojects due for a release")
+	for _, repo := range repos {
+		owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+		release, err := client.getLatestRelease(ctx, owner, name)
+		if err != nil {
+			return err
+		}
+
+		var reason string
+		if release == nil {
+			reason = "has never been released"
+		} else {
+			comparison, _, err := client.Repositories.CompareCommits(ctx, owner, name, release.GetTagName(), repo.GetDefaultBranch(), &github.ListOptions{PerPage: 1})
+			if err != nil {
+				return err
+			}
+			unreleased := comparison.GetAheadBy()
+			switch {
+			case unreleased == 0:
+				continue
+			case release.GetPublishedAt().Before(cutoff):
+				reason = fmt.Sprintf("%d unreleased commits, latest release %s was published on %s", unreleased, release.GetTagName(), release.GetPublishedAt().Format(dateFormat))
+			case unreleased >= *minCommits:
+				reason = fmt.Sprintf("%d unreleased commits since %s", unreleased, release.GetTagName())
+			default:
+				continue
+			}
+		}
+
+		fmt.Printf("- %s %s\n", repo.GetHTMLURL(), reason)
+		if *openIssues {
+			ref := issueRef{Repo: repo.GetFullName(), Title: staleReleaseIssueTitle}
+			body := fmt.Sprintf("This repository %s. Please consider cutting a new release.\n\nThis issue is maintained by the [SIG Auth tools](https://github.com/kubernetes-sigs/sig-auth-tools).\n", reason)
+			if _, err := client.upsertIssue(ctx, ref, body); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getLatestRelease returns the latest published release of the repository, or
+// nil if there is none.
+func (c *ghClient) getLatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, error) {
+	release, resp, err := c.Repositories.GetLatestRelease(ctx, owner, repo)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	return release, err
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// tideContext is the status context Tide reports the merge-pool state in.
+const tideContext = "tide"
+
+// runTideReport lists open PRs that Tide is not merging, with the reason Tide
+// gives and the failing status contexts.
+func runTideReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report tide", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	fmt.Println("# PRs blocked from merging")
+	for _, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !src.includesRepo(repo) {
+				continue
+			}
+			issues, err := client.listIssuesAndPullRequests(ctx, repo.GetOwner().GetLogin(), *repo.Name, src.Labels...)
+			if err != nil {
+				return err
+			}
+			for _, issue := range issues {
+				if !issue.IsPullRequest() {
+					continue
+				}
+				pr, _, err := client.PullRequests.Get(ctx, repo.GetOwner().GetLogin(), *repo.Name, issue.GetNumber())
+				if err != nil {
+					return err
+				}
+				status, _, err := client.Repositories.GetCombinedStatus(ctx, repo.GetOwner().GetLogin(), *repo.Name, pr.GetHead().GetSHA(), &github.ListOptions{PerPage: perPage})
+				if err != nil {
+					return err
+				}
+
+				var tide *github.RepoStatus
+				var failing []string
+				for _, s := range status.Statuses {
+					switch {
+					case s.GetContext() == tideContext:
+						tide = s
+					case s.GetState() == "failure" || s.GetState() == "error":
+						failing = append(failing, s.GetContext())
+					}
+				}
+				// PRs without a Tide context are in repositories Tide does
+				// not merge.
+				if tide == nil || tide.GetState() == "success" {
+					continue
+				}
+
+				fmt.Printf("\n- %s %s\n  - %s\n", pr.GetHTMLURL(), pr.GetTitle(), tide.GetDescription())
+				if len(failing) > 0 {
+					fmt.Printf("  - failing: %s\n", strings.Join(failing, ", "))
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// announcedItems are the untriaged items already notified, by item ID, with
+// the time of the notification.
+type announcedItems map[string]time.Time
+
+// runUntriagedReport lists the untriaged items of the board that were not
+// announced yet, with a count of the whole backlog, so that notifications do
+// not repeat the items posted the previous times.
+func runUntriagedReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("report untriaged", flag.ExitOnError)
+	common.register(fs)
+	announcedFile := fs.String("announced", "", "file keeping track of the items already announced, all untriaged items are new when empty")
+	webhook := fs.String("webhook", os.Getenv("UNTRIAGED_WEBHOOK_URL"), "also post the report to this Slack incoming webhook, defaults to $UNTRIAGED_WEBHOOK_URL")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	announced := announcedItems{}
+	if *announcedFile != "" {
+		if announced, err = readAnnouncedItems(*announcedFile); err != nil {
+			return err
+		}
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+	snap := newSnapshot(project, items)
+	untriaged := untriagedItems(prof, snap)
+
+	// Items that left the backlog are forgotten, so they are announced again
+	// if they come back to an untriaged status.
+	next := announcedItems{}
+	var fresh []snapshotItem
+	for _, item := range untriaged {
+		if at, ok := announced[item.ID]; ok {
+			next[item.ID] = at
+			continue
+		}
+		next[item.ID] = snap.Time
+		fresh = append(fresh, item)
+	}
+
+	if len(fresh) == 0 {
+		fmt.Printf("No new untriaged items, %d in the backlog\n", len(untriaged))
+		return writeAnnouncedItems(*announcedFile, next)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# New untriaged items on the %s board (%d)\n\n", prof.Project, len(fresh))
+	for _, item := range fresh {
+		fmt.Fprintf(&b, "- %s %s\n", item.URL, item.Title)
+	}
+	fmt.Fprintf(&b, "\nBacklog: %d untriaged items, %d new since the last notification.\n", len(untriaged), len(fresh))
+	fmt.Print(b.String())
+
+	if *webhook != "" {
+		if err := postWebhook(ctx, *webhook, map[string]string{"text": b.String()}); err != nil {
+			// The items are announced again on the next run.
+			return err
+		}
+	}
+	return writeAnnouncedItems(*announcedFile, next)
+}
+
+// untriagedItems returns the open items of the snapshot that are still in an
+// initial status and not snoozed.
+func untriagedItems(prof profile, snap *boardSnapshot) []snapshotItem {
+	var items []snapshotItem
+	for _, item := range snap.Items {
+		if _, active := snoozed(item.Fields, snap.Time); active || item.State != "OPEN" || !prof.isUntriaged(item.Status) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// readAnnouncedItems reads the announced items file, empty if it does not
+// exist yet.
+func readAnnouncedItems(path string) (announcedItems, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return announcedItems{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	announced := announcedItems{}
+	if err := json.Unmarshal(data, &announced); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return announced, nil
+}
+
+// writeAnnouncedItems replaces the announced items file, if any.
+func writeAnnouncedItems(path string, announced announcedItems) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(announced, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// releaseSchedule holds the milestones of the Kubernetes release in progress,
+// as published by SIG Release in kubernetes/sig-release. Dates are in
+// YYYY-MM-DD form.
+type releaseSchedule struct {
+	// Release is the release milestone, e.g. v1.34.
+	Release string `json:"release"`
+	// Start is the first day of the release cycle.
+	Start              string `json:"start,omitempty"`
+	EnhancementsFreeze string `json:"enhancementsFreeze"`
+	CodeFreeze         string `json:"codeFreeze"`
+	TestFreeze         string `json:"testFreeze"`
+	// ReleaseDate is the date the release is cut.
+	ReleaseDate string `json:"releaseDate,omitempty"`
+}
+
+// freeze is a deadline of the release schedule.
+type freeze struct {
+	Name string
+	Date time.Time
+}
+
+// freezes returns the deadlines of the schedule in chronological order.
+func (s *releaseSchedule) freezes() ([]freeze, error) {
+	var freezes []freeze
+	for _, f := range []struct{ name, date string }{
+		{"Enhancements freeze", s.EnhancementsFreeze},
+		{"Code freeze", s.CodeFreeze},
+		{"Test freeze", s.TestFreeze},
+		{"Release", s.ReleaseDate},
+	} {
+		if f.date == "" {
+			continue
+		}
+		date, err := time.Parse(dateFormat, f.date)
+		if err != nil {
+			return nil, fmt.Errorf("release schedule: invalid %s date: %w", f.name, err)
+		}
+		freezes = append(freezes, freeze{Name: f.name, Date: date})
+	}
+	return freezes, nil
+}
+
+// upcoming returns the deadlines of the schedule that are at most within of
+// now and not yet passed.
+func (s *releaseSchedule) upcoming(now time.Time, within time.Duration) ([]freeze, error) {
+	freezes, err := s.freezes()
+	if err != nil {
+		return nil, err
+	}
+	// Freezes are in effect from the start of their day, in UTC.
+	today := now.UTC().Truncate(24 * time.Hour)
+	var upcoming []freeze
+	for _, f := range freezes {
+		if !f.Date.Before(today) && f.Date.Sub(today) <= within {
+			upcoming = append(upcoming, f)
+		}
+	}
+	return upcoming, nil
+}
+
+// releasePhase is a phase of the release cycle, matching an iteration of the
+// board.
+type releasePhase struct {
+	Title string
+	Start time.Time
+	// End is the first day after the phase.
+	End time.Time
+}
+
+// phases returns the phases of the release cycle: enhancements until the
+// enhancements freeze, development until the code freeze and stabilization
+// until the release.
+func (s *releaseSchedule) phases() ([]releasePhase, error) {
+	var dates []time.Time
+	for _, d := range []struct{ name, date string }{
+		{"start", s.Start},
+		{"enhancementsFreeze", s.EnhancementsFreeze},
+		{"codeFreeze", s.CodeFreeze},
+		{"releaseDate", s.ReleaseDate},
+	} {
+		if d.date == "" {
+			return nil, fmt.Errorf("release schedule: %s is required for iterations", d.name)
+		}
+		date, err := time.Parse(dateFormat, d.date)
+		if err != nil {
+			return nil, fmt.Errorf("release schedule: invalid %s date: %w", d.name, err)
+		}
+		if len(dates) > 0 && !date.After(dates[len(dates)-1]) {
+			return nil, fmt.Errorf("release schedule: %s must be after the previous dates", d.name)
+		}
+		dates = append(dates, date)
+	}
+
+	var phases []releasePhase
+	for i, name := range []string{"Enhancements", "Development", "Stabilization"} {
+		phases = append(phases, releasePhase{
+			Title: s.Release + " " + name,
+			Start: dates[i],
+			End:   dates[i+1],
+		})
+	}
+	return phases, nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// apiServer serves the board state, refreshed periodically, as JSON, so that
+// other tooling can read it without a project-scope token.
+type apiServer struct {
+	client  *ghClient
+	project *project
+	profile profile
+	// triageSLA is how long items may stay untriaged.
+	triageSLA time.Duration
+
+	mu   sync.RWMutex
+	snap *boardSnapshot
+}
+
+// slaItem is an untriaged item in the SLA report.
+type slaItem struct {
+	snapshotItem
+	// AgeDays is the number of days since the item was created.
+	AgeDays  int  `json:"ageDays"`
+	Breached bool `json:"breached"`
+}
+
+// slaReport is the response of /reports/sla.
+type slaReport struct {
+	Time      time.Time `json:"time"`
+	SLADays   int       `json:"slaDays"`
+	Untriaged int       `json:"untriaged"`
+	Breached  int       `json:"breached"`
+	Items     []slaItem `json:"items"`
+}
+
+func runServe(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	common.register(fs)
+	api := fs.Bool("api", false, "serve the read-only JSON API of the board state")
+	addr := fs.String("addr", ":8080", "address to listen on")
+	refresh := fs.Duration("refresh", 15*time.Minute, "how often the board state is refreshed")
+	triageSLA := daysFlag{7 * 24 * time.Hour}
+	fs.Var(&triageSLA, "triage-sla", "how long items may stay untriaged, e.g. 7d, for /reports/sla")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !*api {
+		return fmt.Errorf("nothing to serve, set --api")
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	s := &apiServer{client: client, project: project, profile: prof, triageSLA: triageSLA.Duration}
+	if err := s.refresh(ctx); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(*refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			// The previous state keeps being served if the refresh fails.
+			if err := s.refresh(ctx); err != nil {
+				fmt.Printf("failed to refresh the board state: %v\n", err)
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/items", s.serveItems)
+	mux.HandleFunc("/untriaged", s.serveUntriaged)
+	mux.HandleFunc("/reports/sla", s.serveSLA)
+	fmt.Printf("listening on %s\n", *addr)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// refresh replaces the served board state with the current one.
+func (s *apiServer) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+	items, err := s.client.listProjectItems(ctx, s.project)
+	if err != nil {
+		return err
+	}
+	snap := newSnapshot(s.project, items)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snap = snap
+	return nil
+}
+
+func (s *apiServer) snapshot() *boardSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snap
+}
+
+func (s *apiServer) serveItems(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, s.snapshot())
+}
+
+func (s *apiServer) serveUntriaged(w http.ResponseWriter, r *http.Request) {
+	snap := s.snapshot()
+	writeJSON(w, r, &boardSnapshot{Time: snap.Time, Project: snap.Project, Items: untriagedItems(s.profile, snap)})
+}
+
+func (s *apiServer) serveSLA(w http.ResponseWriter, r *http.Request) {
+	snap := s.snapshot()
+	report := &slaReport{Time: snap.Time, SLADays: int(s.triageSLA.Hours() / 24)}
+	for _, item := range untriagedItems(s.profile, snap) {
+		entry := slaItem{snapshotItem: item}
+		if item.CreatedAt != nil {
+			age := snap.Time.Sub(*item.CreatedAt)
+			entry.AgeDays, entry.Breached = int(age.Hours()/24), age > s.triageSLA
+		}
+		report.Untriaged++
+		if entry.Breached {
+			report.Breached++
+		}
+		report.Items = append(report.Items, entry)
+	}
+	writeJSON(w, r, report)
+}
+
+// writeJSON writes v as the JSON response of a GET request.
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "only GET is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// snapshotTimeFormat is the format of snapshot file names, which sorts
+// chronologically.
+const snapshotTimeFormat = "20060102T150405Z"
+
+// boardSnapshot is the state of a project board at a point in time.
+type boardSnapshot struct {
+	Time    time.Time      `json:"time"`
+	Project string         `json:"project"`
+	Items   []snapshotItem `json:"items"`
+	// Backfill marks snapshots written by the backfill command, which only
+	// hold items closed at that time that were never on the board.
+	Backfill bool `json:"backfill,omitempty"`
+}
+
+// snapshotItem is a board item as recorded in a snapshot.
+type snapshotItem struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	ContentID  string            `json:"contentId,omitempty"`
+	Repository string            `json:"repository,omitempty"`
+	Number     int               `json:"number,omitempty"`
+	Title      string            `json:"title,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	State      string            `json:"state,omitempty"`
+	Status     string            `json:"status,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Labels     []string          `json:"labels,omitempty"`
+	CreatedAt  *time.Time        `json:"createdAt,omitempty"`
+}
+
+func newSnapshot(p *project, items []*projectItem) *boardSnapshot {
+	snap := &boardSnapshot{Time: time.Now().UTC(), Project: p.Title}
+	for _, item := range items {
+		snap.Items = append(snap.Items, snapshotItem{
+			ID:         fmt.Sprint(item.ID),
+			Type:       string(item.Type),
+			ContentID:  item.ContentID,
+			Repository: item.Repository,
+			Number:     item.Number,
+			Title:      item.Title,
+			URL:        item.URL,
+			State:      item.State,
+			Status:     item.Status,
+			Fields:     item.values,
+			Labels:     item.Labels,
+			CreatedAt:  timeOrNil(item.CreatedAt),
+		})
+	}
+	return snap
+}
+
+func (s *boardSnapshot) marshal() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// saveSnapshot writes the snapshot to a new file in dir and returns its path.
+func saveSnapshot(dir string, snap *boardSnapshot) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	data, err := snap.marshal()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, snap.Time.Format(snapshotTimeFormat)+".json")
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+// loadSnapshot returns the latest snapshot in dir taken before the given time,
+// skipping backfill snapshots.
+func loadSnapshot(dir string, before time.Time) (*boardSnapshot, error) {
+	paths, err := snapshotPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(paths) - 1; i >= 0; i-- {
+		if !paths[i].taken.Before(before) {
+			continue
+		}
+		snap, err := readSnapshot(paths[i].path)
+		if err != nil {
+			return nil, err
+		}
+		if snap.Backfill {
+			continue
+		}
+		return snap, nil
+	}
+
+	return nil, fmt.Errorf("no snapshot taken before %s found in %q", before.Format(time.RFC3339), dir)
+}
+
+// loadSnapshots returns all snapshots in dir, oldest first.
+func loadSnapshots(dir string) ([]*boardSnapshot, error) {
+	paths, err := snapshotPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var snaps []*boardSnapshot
+	for _, p := range paths {
+		snap, err := readSnapshot(p.path)
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, snap)
+	}
+	if len(snaps) == 0 {
+		return nil, fmt.Errorf("no snapshots found in %q", dir)
+	}
+	return snaps, nil
+}
+
+// snapshotPath is a snapshot file and the time it was taken.
+type snapshotPath struct {
+	path  string
+	taken time.Time
+}
+
+// snapshotPaths returns the snapshot files in dir, oldest first.
+func snapshotPaths(dir string) ([]snapshotPath, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var snapshots []snapshotPath
+	for _, path := range paths {
+		name := filepath.Base(path)
+		taken, err := time.Parse(snapshotTimeFormat, name[:len(name)-len(".json")])
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshotPath{path: path, taken: taken})
+	}
+	return snapshots, nil
+}
+
+func readSnapshot(path string) (*boardSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap boardSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing snapshot %q: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// exportSnapshot commits the snapshot to the file, so that the repository
+// history records the board state over time.
+func (c *ghClient) exportSnapshot(ctx context.Context, file repoFile, snap *boardSnapshot) error {
+	data, err := snap.marshal()
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("Update %s board snapshot", snap.Project)
+	return c.commitFile(ctx, file, data, message)
+}
+
+// commitFile creates or updates the file with the content.
+func (c *ghClient) commitFile(ctx context.Context, file repoFile, content []byte, message string) error {
+	owner, repo, err := splitRepo(file.Repo)
+	if err != nil {
+		return err
+	}
+
+	opts := &github.RepositoryContentFileOptions{
+		Message: github.String(message),
+		Content: content,
+	}
+	if file.Branch != "" {
+		opts.Branch = github.String(file.Branch)
+	}
+
+	existing, _, resp, err := c.Repositories.GetContents(ctx, owner, repo, file.Path, &github.RepositoryContentGetOptions{Ref: file.Branch})
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		_, _, err = c.Repositories.CreateFile(ctx, owner, repo, file.Path, opts)
+	case err != nil:
+		return err
+	default:
+		opts.SHA = existing.SHA
+		_, _, err = c.Repositories.UpdateFile(ctx, owner, repo, file.Path, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("committed %s to %s\n", file.Path, file.Repo)
+	return nil
+}
+
+// timeOrNil returns nil for the zero time, so that it is omitted from JSON.
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
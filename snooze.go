@@ -0,0 +1,65 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// snoozeUntilFieldName is the name of the date field chairs set to defer an
+// item until a date.
+const snoozeUntilFieldName = "Snooze until"
+
+// snoozed reports whether the field values have a Snooze until date, and
+// whether it is still in the future. Items resurface on the snooze date.
+func snoozed(values map[string]string, now time.Time) (set, active bool) {
+	value, ok := values[snoozeUntilFieldName]
+	if !ok {
+		return false, false
+	}
+	until, err := time.Parse(dateFormat, value)
+	if err != nil {
+		return false, false
+	}
+	return true, now.Before(until)
+}
+
+// resurface moves an item whose snooze date passed back to its initial status
+// and clears the date, so that it is triaged again.
+func (s *syncer) resurface(ctx context.Context, src source, item *projectItem, issue *github.Issue) error {
+	status := s.profile.initialStatus(src, issue)
+	fmt.Printf("snooze of [%d] expired, moving from %q to %q\n", *issue.Number, item.Status, status)
+	if err := s.client.clearField(ctx, s.project, item, snoozeUntilFieldName); err != nil {
+		return err
+	}
+	if status == item.Status {
+		return nil
+	}
+	if !s.project.hasOption(statusFieldName, status) {
+		fmt.Printf("no %q option %q, leaving [%d] in %q\n", statusFieldName, status, *issue.Number, item.Status)
+		return nil
+	}
+	moved, err := s.client.setStatus(ctx, s.project, item, status)
+	if moved {
+		s.stats.moved++
+	}
+	return err
+}
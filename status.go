@@ -0,0 +1,168 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+const (
+	// statusFieldName is the name of the project field holding the item status.
+	statusFieldName = "Status"
+	// statusNeedsTriage is the status of newly imported items.
+	statusNeedsTriage = "Needs Triage"
+	// statusPRsNeedsReview is the status of newly imported PRs.
+	statusPRsNeedsReview = "PRs - Needs Review"
+	// statusSubprojectsNeedsTriage is the status of newly imported subproject items.
+	statusSubprojectsNeedsTriage = "Subprojects - Needs Triage"
+	// statusDocsNeedsTriage is the status of newly imported kubernetes/website items.
+	statusDocsNeedsTriage = "Docs - Needs Triage"
+	// statusDeprecations is the status of newly imported kind/deprecation items.
+	statusDeprecations = "Deprecations"
+	// statusCISignal is the status of newly imported kind/failing-test items,
+	// which are release-blocking.
+	statusCISignal = "CI Signal"
+	// statusNeedsReview is the status of newly imported items on the PR review board.
+	statusNeedsReview = "Needs Review"
+	// statusNeedsApprover is the status of PRs that have lgtm but are not yet approved.
+	statusNeedsApprover = "Needs Approver"
+	// statusWaitingOnAuthor is the status of PRs that have changes requested.
+	statusWaitingOnAuthor = "Waiting on Author"
+	// statusRecentlyClosed is the status of closed items imported with
+	// --include-closed-within, reviewed to catch premature closes.
+	statusRecentlyClosed = "Recently Closed"
+)
+
+// derivedStatuses are the statuses the tool assigns based on the item state,
+// in addition to the initial statuses of each source.
+var derivedStatuses = map[string]bool{
+	"":                    true,
+	statusNeedsApprover:   true,
+	statusWaitingOnAuthor: true,
+	statusRecentlyClosed:  true,
+	// Bug reports move back to their initial status once the missing
+	// information is added.
+	statusNeedsInformation: true,
+}
+
+func (s *syncer) reconcileStatus(ctx context.Context, src source, item *projectItem, issue *github.Issue, pr *pullRequest) error {
+	set, active := snoozed(item.values, time.Now())
+	var status string
+	switch {
+	case s.resetStatus != "":
+		status = s.resetStatus
+	case active:
+		// Snoozed items are left alone until their snooze date.
+		return nil
+	case set:
+		return s.resurface(ctx, src, item, issue)
+	case s.profile.isReconcilable(item.Status):
+		status = s.managedStatus(src, issue, pr)
+	default:
+		return nil
+	}
+
+	if status == item.Status {
+		return nil
+	}
+
+	if !s.project.hasOption(statusFieldName, status) {
+		fmt.Printf("no %q option %q, leaving [%d] in %q\n", statusFieldName, status, *issue.Number, item.Status)
+		return nil
+	}
+	fmt.Printf("moving [%d] from %q to %q\n", *issue.Number, item.Status, status)
+	moved, err := s.client.setStatus(ctx, s.project, item, status)
+	if err != nil || !moved {
+		return err
+	}
+	s.stats.moved++
+
+	if s.resetStatus != "" {
+		return nil
+	}
+	switch {
+	case status == statusCISignal && s.profile.CISignalContact != "":
+		return s.pingCISignalContact(ctx, issue)
+	case status == statusNeedsInformation:
+		return s.askForInformation(ctx, issue)
+	}
+	return nil
+}
+
+// managedStatus returns the status a tool-managed item from the source should
+// be in.
+func (s *syncer) managedStatus(src source, issue *github.Issue, pr *pullRequest) string {
+	initial := s.profile.initialStatus(src, issue)
+	switch {
+	case src.AutomationStatus != "" && issue.IsPullRequest() && s.botAuthors[issue.GetUser().GetLogin()]:
+		initial = src.AutomationStatus
+	case s.profile.isExceptionRequest(issue):
+		initial = statusExceptions
+	case s.profile.NeedsInformation != nil && len(missingInformation(issue)) > 0:
+		initial = statusNeedsInformation
+	}
+	return desiredStatus(issue, pr, initial)
+}
+
+// pingCISignalContact mentions the SIG's CI signal contact on a failing-test
+// item, since those need more urgency than regular triage.
+func (s *syncer) pingCISignalContact(ctx context.Context, issue *github.Issue) error {
+	owner, repo := issueRepo(issue)
+	fmt.Printf("pinging @%s on failing test %s/%s#%d\n", s.profile.CISignalContact, owner, repo, *issue.Number)
+	body := fmt.Sprintf("@%s this failing test is owned by SIG Auth and was moved to %s on the %s board.", s.profile.CISignalContact, statusCISignal, s.profile.Project)
+	_, _, err := s.client.Issues.CreateComment(ctx, owner, repo, *issue.Number, &github.IssueComment{
+		Body: github.String(body),
+	})
+	s.client.changelog.record(auditEntry{Action: changelogComment, Content: issue.GetHTMLURL(), After: body}, err)
+	return err
+}
+
+// desiredStatus returns the status a tool-managed item should be in. pr is nil
+// for issues. Items whose derived status no longer applies fall back to the
+// initial status.
+func desiredStatus(issue *github.Issue, pr *pullRequest, initial string) string {
+	switch {
+	case issue.GetState() == "closed":
+		return statusRecentlyClosed
+	case pr != nil && pr.ReviewDecision == githubql.PullRequestReviewDecisionChangesRequested:
+		// The PR is blocked on the author, not on reviewers.
+		return statusWaitingOnAuthor
+	case needsApprover(issue):
+		return statusNeedsApprover
+	}
+	return initial
+}
+
+// needsApprover reports whether the issue is a PR that has lgtm but not approved,
+// meaning it is waiting on an approver rather than a reviewer.
+func needsApprover(issue *github.Issue) bool {
+	return issue.IsPullRequest() && hasLabel(issue, "lgtm") && !hasLabel(issue, "approved")
+}
+
+func hasLabel(issue *github.Issue, name string) bool {
+	for _, label := range issue.Labels {
+		if label.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+func TestDesiredStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		state  string
+		pr     *pullRequest
+		labels []string
+		want   string
+	}{{
+		name: "open issue",
+		want: statusNeedsTriage,
+	}, {
+		name:  "closed issue",
+		state: "closed",
+		want:  statusRecentlyClosed,
+	}, {
+		name:   "closed PR with changes requested",
+		state:  "closed",
+		pr:     &pullRequest{ReviewDecision: githubql.PullRequestReviewDecisionChangesRequested},
+		labels: []string{"lgtm"},
+		want:   statusRecentlyClosed,
+	}, {
+		name:   "changes requested",
+		pr:     &pullRequest{ReviewDecision: githubql.PullRequestReviewDecisionChangesRequested},
+		labels: []string{"lgtm"},
+		want:   statusWaitingOnAuthor,
+	}, {
+		name:   "lgtm without approval",
+		pr:     &pullRequest{ReviewDecision: githubql.PullRequestReviewDecisionReviewRequired},
+		labels: []string{"lgtm"},
+		want:   statusNeedsApprover,
+	}, {
+		name:   "lgtm and approved",
+		pr:     &pullRequest{ReviewDecision: githubql.PullRequestReviewDecisionApproved},
+		labels: []string{"lgtm", "approved"},
+		want:   statusNeedsTriage,
+	}, {
+		name: "PR without review",
+		pr:   &pullRequest{},
+		want: statusNeedsTriage,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := &github.Issue{State: github.String("open")}
+			if tc.state != "" {
+				issue.State = github.String(tc.state)
+			}
+			if tc.pr != nil {
+				issue.PullRequestLinks = &github.PullRequestLinks{}
+			}
+			for _, label := range tc.labels {
+				issue.Labels = append(issue.Labels, &github.Label{Name: github.String(label)})
+			}
+			if got := desiredStatus(issue, tc.pr, statusNeedsTriage); got != tc.want {
+				t.Errorf("desiredStatus() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
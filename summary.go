@@ -0,0 +1,142 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+const (
+	// summaryFieldName is the name of the text field holding the generated
+	// summary of newly imported items.
+	summaryFieldName = "Summary"
+	// maxSummaryInput is the number of characters of the item body sent to the
+	// summarizer, enough for the substance of long bug reports.
+	maxSummaryInput = 8000
+	// summaryPrompt instructs the model to reply with a summary and component.
+	summaryPrompt = `You help triage Kubernetes SIG Auth GitHub issues and pull requests. ` +
+		`Reply with only a JSON object with a "summary" key holding a one-sentence summary of the item, ` +
+		`and a "component" key holding the Kubernetes component it is about, such as kube-apiserver or kubelet, or an empty string if unclear.`
+)
+
+// summarizer is an OpenAI-compatible chat completions endpoint generating the
+// summaries. The API key, if any, is read from $SUMMARY_API_KEY.
+type summarizer struct {
+	// Endpoint is the base URL of the API, e.g. https://api.openai.com/v1.
+	Endpoint string `json:"endpoint"`
+	// Model is the model to use.
+	Model string `json:"model"`
+}
+
+// syncSummary sets the Summary field of newly imported items to a generated
+// one-sentence summary and suggested component, speeding up batch triage of
+// long reports. The field is then left to the triagers.
+func (s *syncer) syncSummary(ctx context.Context, item *projectItem, issue *github.Issue) error {
+	if s.profile.Summarizer == nil || !item.added || item.values[summaryFieldName] != "" {
+		return nil
+	}
+	summary, err := s.profile.Summarizer.summarize(ctx, issue)
+	if err != nil {
+		// The summary is a convenience, the sync goes on without it.
+		fmt.Printf("failed to summarize %s: %v\n", issue.GetHTMLURL(), err)
+		return nil
+	}
+	if summary == "" {
+		return nil
+	}
+	return s.client.setTextField(ctx, s.project, item, summaryFieldName, summary)
+}
+
+// summarize returns the summary of the issue, followed by the suggested
+// component if any.
+func (m *summarizer) summarize(ctx context.Context, issue *github.Issue) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	body := htmlCommentRE.ReplaceAllString(issue.GetBody(), "")
+	if len(body) > maxSummaryInput {
+		body = body[:maxSummaryInput]
+	}
+	request := map[string]interface{}{
+		"model": m.Model,
+		"messages": []map[string]string{
+			{"role": "system", "content": summaryPrompt},
+			{"role": "user", "content": issue.GetTitle() + "\n\n" + body},
+		},
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(m.Endpoint, "/")+"/chat/completions", bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key := os.Getenv("SUMMARY_API_KEY"); key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("summarizer failed with status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return "", err
+	}
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("summarizer returned no choices")
+	}
+
+	var reply struct {
+		Summary   string `json:"summary"`
+		Component string `json:"component"`
+	}
+	content := strings.TrimSpace(completion.Choices[0].Message.Content)
+	// Models tend to wrap JSON in a code block.
+	content = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(content, "```json"), "```"), "```")
+	if err := json.Unmarshal([]byte(content), &reply); err != nil {
+		return "", fmt.Errorf("unexpected summarizer reply %q: %w", content, err)
+	}
+	summary := strings.TrimSpace(reply.Summary)
+	if component := strings.TrimSpace(reply.Component); summary != "" && component != "" {
+		summary += fmt.Sprintf(" (component: %s)", component)
+	}
+	return summary, nil
+}
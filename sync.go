@@ -0,0 +1,440 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// mutationGracePeriod is how long an item being synced may take to finish
+// after the run is cancelled.
+const mutationGracePeriod = 30 * time.Second
+
+// syncer syncs issues and PRs into a project board according to a profile.
+type syncer struct {
+	client  *ghClient
+	project *project
+	profile profile
+	filter  itemFilter
+	// botAuthors are the logins of the automation accounts of the config.
+	botAuthors map[string]bool
+	// schedule is the schedule of the release in progress, nil if unknown.
+	schedule *releaseSchedule
+
+	// removeStaleAccepted enables removing lifecycle/stale from accepted items.
+	removeStaleAccepted bool
+	// resetStatus, if set, is the status all synced items are moved to,
+	// regardless of their current status.
+	resetStatus string
+	// includeClosedWithin, if set, also imports items closed within this
+	// duration into statusRecentlyClosed.
+	includeClosedWithin time.Duration
+	// fields are the names of the fields synced by the run, lower-cased, nil
+	// meaning all fields.
+	fields map[string]bool
+	// itemsByRef are the items synced by the run by owner/repo#number
+	// reference, and parents the umbrella issue of the referenced items.
+	// Both are nil outside of run.
+	itemsByRef map[string]*projectItem
+	parents    map[string]string
+	// batchSize is the number of field updates sent per request, zero meaning
+	// that updates are sent immediately.
+	batchSize int
+
+	stats *runStats
+}
+
+func runSync(ctx context.Context, tokens, args []string) (err error) {
+	var common commonFlags
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	common.register(fs)
+	milestone := fs.String("milestone", "", "only sync items targeting this milestone, e.g. v1.34")
+	includeBots := fs.Bool("include-bots", false, "also sync items authored by the bot accounts in the config")
+	removeStaleAccepted := fs.Bool("remove-stale-accepted", false, "comment /remove-lifecycle stale on accepted items that went stale")
+	resetStatus := fs.String("reset-status", "", "move all synced items to this status regardless of their current status, requires --repos or --labels")
+	var repos, labels, fields stringList
+	fs.Var(&fields, "fields", "comma-separated list of the board fields to sync, e.g. Status for a quick status-only sync, defaults to all fields")
+	fs.Var(&repos, "repos", "comma-separated list of owner/name repositories to restrict the sync to")
+	fs.Var(&labels, "labels", "comma-separated list of labels items must carry, in addition to the source labels")
+	fs.Var(&labels, "label", "label items must carry, may be repeated, same as --labels")
+	var createdAfter, createdBefore dateFlag
+	fs.Var(&createdAfter, "created-after", "only sync items created on or after this date, e.g. 2023-05-01")
+	fs.Var(&createdBefore, "created-before", "only sync items created before this date, e.g. 2023-05-08")
+	auditLogPath := fs.String("audit-log", "", "append a JSON line for every board mutation to this file")
+	snapshotDir := fs.String("snapshot-dir", "", "write a JSON snapshot of the board to this directory after syncing")
+	pushgatewayURL := fs.String("pushgateway-url", "", "push run metrics to this Prometheus Pushgateway")
+	var includeClosedWithin daysFlag
+	fs.Var(&includeClosedWithin, "include-closed-within", "also import items closed within this duration, e.g. 7d, into the Recently Closed status")
+	batchSize := fs.Int("batch-size", 20, "number of field updates to send per request, 0 sends each update immediately")
+	changelogPath := fs.String("changelog", "", "write a JSON artifact listing every action of the run and its outcome to this file")
+	failureWebhook := fs.String("failure-webhook", os.Getenv("FAILURE_WEBHOOK_URL"), "post an alert to this webhook when the run fails, defaults to $FAILURE_WEBHOOK_URL")
+	failureWebhookFormat := fs.String("failure-webhook-format", "json", "payload format of the failure webhook, json or slack")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.Arg(0) == "repo" {
+		// sync repo <owner/name> syncs a single repository, e.g. right after
+		// labeling a batch of its issues, instead of waiting for the daily run.
+		if fs.NArg() < 2 {
+			return fmt.Errorf("usage: sync repo <owner/name> [--label ...]")
+		}
+		if _, _, err := splitRepo(fs.Arg(1)); err != nil {
+			return err
+		}
+		repos = stringList{fs.Arg(1)}
+		if err := fs.Parse(fs.Args()[2:]); err != nil {
+			return err
+		}
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *failureWebhookFormat != "json" && *failureWebhookFormat != "slack" {
+		return fmt.Errorf("invalid --failure-webhook-format %q, must be json or slack", *failureWebhookFormat)
+	}
+
+	stats := &runStats{start: time.Now(), phase: "loading the config"}
+	if *failureWebhook != "" {
+		defer func() {
+			if err == nil {
+				return
+			}
+			if alertErr := postFailureAlert(*failureWebhook, *failureWebhookFormat, common.profileName, stats, err); alertErr != nil {
+				fmt.Printf("failed to post failure alert: %v\n", alertErr)
+			}
+		}()
+	}
+	if !createdAfter.IsZero() && !createdBefore.IsZero() && !createdAfter.Before(createdBefore.Time) {
+		return fmt.Errorf("--created-after must be before --created-before")
+	}
+	if *resetStatus != "" && len(repos) == 0 && len(labels) == 0 {
+		return fmt.Errorf("--reset-status must be scoped with --repos or --labels")
+	}
+
+	cfg, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	filter := itemFilter{
+		milestone:        *milestone,
+		pullRequestsOnly: prof.PullRequestsOnly,
+		repos:            repoSet(repos),
+		labels:           labels,
+		createdAfter:     createdAfter.Time,
+		createdBefore:    createdBefore.Time,
+		exemptLabels:     cfg.ExemptLabels,
+	}
+	if !*includeBots {
+		filter.excludedAuthors = stringSet(cfg.BotAuthors)
+	}
+
+	client := newClient(ctx, tokens)
+	if *pushgatewayURL != "" {
+		defer func() {
+			if pushErr := pushMetrics(*pushgatewayURL, common.profileName, client, stats, err); pushErr != nil {
+				fmt.Printf("failed to push metrics: %v\n", pushErr)
+			}
+		}()
+	}
+	if *changelogPath != "" {
+		client.changelog = &changelog{start: stats.start}
+		defer func() {
+			if writeErr := client.changelog.write(*changelogPath, common.profileName, err); writeErr != nil {
+				fmt.Printf("failed to write changelog: %v\n", writeErr)
+			}
+		}()
+	}
+	if *auditLogPath != "" {
+		client.audit, err = openAuditLog(*auditLogPath)
+		if err != nil {
+			return err
+		}
+		defer client.audit.Close()
+	}
+	stats.phase = "getting the project"
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	if *resetStatus != "" && !project.hasOption(statusFieldName, *resetStatus) {
+		return fmt.Errorf("unknown --reset-status %q, project %q has no such %q option", *resetStatus, project.Title, statusFieldName)
+	}
+
+	s := &syncer{
+		stats:               stats,
+		client:              client,
+		project:             project,
+		profile:             prof,
+		filter:              filter,
+		botAuthors:          stringSet(cfg.BotAuthors),
+		schedule:            cfg.ReleaseSchedule,
+		removeStaleAccepted: *removeStaleAccepted,
+		resetStatus:         *resetStatus,
+		batchSize:           *batchSize,
+		includeClosedWithin: includeClosedWithin.Duration,
+	}
+	if len(fields) > 0 {
+		s.fields = map[string]bool{}
+		for name := range project.fields {
+			s.fields[strings.ToLower(name)] = false
+		}
+		for _, name := range fields {
+			if _, ok := s.fields[strings.ToLower(name)]; !ok {
+				return fmt.Errorf("unknown --fields field %q in project %q", name, project.Title)
+			}
+			s.fields[strings.ToLower(name)] = true
+		}
+	}
+	stats.phase = "checking the built-in workflows"
+	if err := s.checkWorkflows(ctx); err != nil {
+		return err
+	}
+	stats.phase = "checking the item limit"
+	if err := s.checkItemLimit(ctx); err != nil {
+		return err
+	}
+	stats.phase = "syncing items"
+	if err := s.run(ctx); err != nil {
+		return err
+	}
+	stats.phase = "pruning exempt items"
+	if err := s.pruneExempt(ctx); err != nil {
+		return err
+	}
+	stats.phase = "applying policies"
+	if err := s.applyPolicies(ctx); err != nil {
+		return err
+	}
+
+	stats.phase = "exporting the results"
+	if prof.RunLogIssue != nil {
+		if err := client.postRunSummary(ctx, *prof.RunLogIssue, project, stats); err != nil {
+			return err
+		}
+	}
+
+	if *snapshotDir == "" && prof.SnapshotExport == nil && prof.BigQueryExport == nil {
+		return nil
+	}
+
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+	snap := newSnapshot(project, items)
+	if *snapshotDir != "" {
+		path, err := saveSnapshot(*snapshotDir, snap)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("saved board snapshot to %s\n", path)
+	}
+	if prof.SnapshotExport != nil {
+		if err := client.exportSnapshot(ctx, *prof.SnapshotExport, snap); err != nil {
+			return err
+		}
+	}
+	if prof.BigQueryExport != nil {
+		if err := exportToBigQuery(ctx, *prof.BigQueryExport, snap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syncer) run(ctx context.Context) (err error) {
+	if s.batchSize > 0 {
+		s.client.queue = &updateQueue{size: s.batchSize}
+		defer func() {
+			// Queued updates are flushed even if the run was cancelled, so
+			// that no item is left without the status it was given.
+			flushCtx, cancel := context.WithTimeout(context.Background(), mutationGracePeriod)
+			defer cancel()
+			if flushErr := s.client.flushUpdates(flushCtx); flushErr != nil && err == nil {
+				err = flushErr
+			}
+			if failed := s.client.queue.failed; failed > 0 && err == nil {
+				err = fmt.Errorf("%d of %d queued field updates failed", failed, s.client.queue.queued)
+			}
+			s.client.queue = nil
+		}()
+	}
+
+	if s.syncsField(parentFieldName) {
+		s.itemsByRef, s.parents = map[string]*projectItem{}, map[string]string{}
+		defer func() { s.itemsByRef, s.parents = nil, nil }()
+	}
+
+	for _, src := range s.profile.Sources {
+		if err := s.syncSource(ctx, src); err != nil {
+			return err
+		}
+	}
+	if err := s.syncAPIReview(ctx); err != nil {
+		return err
+	}
+	return s.syncParents(ctx)
+}
+
+func (s *syncer) syncSource(ctx context.Context, src source) error {
+	repos, err := s.client.listSourceRepos(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		owner := repo.GetOwner().GetLogin()
+		if !src.includesRepo(repo) || !s.filter.includesRepo(owner, *repo.Name) {
+			continue
+		}
+		fmt.Printf("Looking for issues and PRs in %s/%s\n", owner, *repo.Name)
+
+		items, err := s.client.listIssuesAndPullRequests(ctx, owner, *repo.Name, src.Labels...)
+		if err != nil {
+			return err
+		}
+		if s.includeClosedWithin > 0 {
+			closed, err := s.listRecentlyClosed(ctx, src, owner, *repo.Name)
+			if err != nil {
+				return err
+			}
+			items = append(items, closed...)
+		}
+		items = s.filter.forSource(src).filterItems(items)
+
+		fmt.Printf("found %d in repo %s/%s\n", len(items), owner, *repo.Name)
+		s.stats.synced += len(items)
+		for _, item := range items {
+			// Stop picking up items once cancelled, the remaining ones are
+			// synced by the next run.
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("stopped before syncing %s: %w", item.GetHTMLURL(), err)
+			}
+			fmt.Printf("adding [%d] %s to project\n", *item.Number, *item.Title)
+			if err := s.syncItem(ctx, src, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// listRecentlyClosed returns the items of the source in the repository that
+// were closed within includeClosedWithin.
+func (s *syncer) listRecentlyClosed(ctx context.Context, src source, owner, repo string) ([]*github.Issue, error) {
+	since := time.Now().Add(-s.includeClosedWithin)
+	closed, err := s.client.listIssues(ctx, owner, repo, &github.IssueListByRepoOptions{
+		State:  "closed",
+		Labels: src.Labels,
+		Since:  since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var recent []*github.Issue
+	for _, issue := range closed {
+		// Since filters on the update time, which may be later than the close time.
+		if issue.GetClosedAt().After(since) {
+			recent = append(recent, issue)
+		}
+	}
+	return recent, nil
+}
+
+// syncItem runs addAndUpdateProjectItem without letting cancellation of ctx
+// interrupt it half-way, which would leave the item on the board without a
+// status. Once ctx is done, the item gets mutationGracePeriod to finish and is
+// recorded in the run stats if it does not.
+func (s *syncer) syncItem(ctx context.Context, src source, issue *github.Issue) error {
+	itemCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-itemCtx.Done():
+			return
+		}
+		timer := time.NewTimer(mutationGracePeriod)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-itemCtx.Done():
+		}
+	}()
+
+	err := s.addAndUpdateProjectItem(itemCtx, src, issue)
+	if err != nil {
+		s.client.changelog.record(auditEntry{Action: changelogSync, Project: s.project.Title, Content: issue.GetHTMLURL()}, err)
+	}
+	if err != nil && ctx.Err() != nil {
+		fmt.Printf("interrupted while syncing %s, its status and fields may be incomplete\n", issue.GetHTMLURL())
+		s.stats.interrupted = append(s.stats.interrupted, issue.GetHTMLURL())
+	}
+	return err
+}
+
+// addAndUpdateProjectItem adds the issue or PR to the project, reconciles its
+// status and syncs its fields.
+func (s *syncer) addAndUpdateProjectItem(ctx context.Context, src source, issue *github.Issue) error {
+	item, err := s.client.addProjectV2ItemById(ctx, s.project, *issue.NodeID)
+	if err != nil {
+		return err
+	}
+	if item.added {
+		s.stats.added = append(s.stats.added, item.URL)
+	}
+	s.recordHierarchy(item, issue)
+
+	// The review state is only needed by the status and the unresolved threads.
+	var pr *pullRequest
+	if issue.IsPullRequest() && (s.syncsField(statusFieldName) || s.syncsField(unresolvedThreadsFieldName)) {
+		pr, err = s.client.getPullRequest(ctx, *issue.NodeID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.syncsField(statusFieldName) {
+		if err := s.reconcileStatus(ctx, src, item, issue, pr); err != nil {
+			return err
+		}
+	}
+
+	if s.removeStaleAccepted {
+		if err := s.removeStaleFromAccepted(ctx, item, issue); err != nil {
+			return err
+		}
+	}
+
+	return s.syncFields(ctx, src, item, issue, pr)
+}
+
+// syncsField reports whether the run syncs the field, i.e. it is selected by
+// --fields and defined on the project.
+func (s *syncer) syncsField(name string) bool {
+	return (s.fields == nil || s.fields[strings.ToLower(name)]) && s.project.hasField(name)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// sigDir is the directory of SIG Auth in kubernetes/community.
+	sigDir = "sig-auth"
+	// leadsTeam is the GitHub team of the SIG chairs and tech leads.
+	leadsTeam = sigDir + "-leads"
+)
+
+// sigsFile is the part of sigs.yaml in kubernetes/community used by the team audit.
+type sigsFile struct {
+	SIGs []struct {
+		Dir        string `json:"dir"`
+		Leadership struct {
+			Chairs    []sigsPerson `json:"chairs"`
+			TechLeads []sigsPerson `json:"tech_leads"`
+		} `json:"leadership"`
+	} `json:"sigs"`
+}
+
+type sigsPerson struct {
+	GitHub string `json:"github"`
+}
+
+// ownersAliasesFile is the OWNERS_ALIASES file of kubernetes/kubernetes.
+type ownersAliasesFile struct {
+	Aliases map[string][]string `json:"aliases"`
+}
+
+// runAuditTeams compares the members of the sig-auth-* GitHub teams with the
+// SIG leadership in sigs.yaml and the sig-auth-* aliases in OWNERS_ALIASES.
+func runAuditTeams(ctx context.Context, tokens, args []string) error {
+	fs := flag.NewFlagSet("audit-teams", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	var sigs sigsFile
+	if err := client.getYAMLFile(ctx, orgName, "community", "sigs.yaml", &sigs); err != nil {
+		return err
+	}
+	var owners ownersAliasesFile
+	if err := client.getYAMLFile(ctx, orgName, "kubernetes", "OWNERS_ALIASES", &owners); err != nil {
+		return err
+	}
+
+	leads := loginSet{}
+	for _, sig := range sigs.SIGs {
+		if sig.Dir != sigDir {
+			continue
+		}
+		for _, p := range append(sig.Leadership.Chairs, sig.Leadership.TechLeads...) {
+			leads.add(p.GitHub)
+		}
+	}
+	aliases := map[string]loginSet{}
+	listed := loginSet{}
+	for name, members := range owners.Aliases {
+		if !strings.HasPrefix(name, sigDir+"-") {
+			continue
+		}
+		aliases[name] = loginSet{}
+		for _, member := range members {
+			aliases[name].add(member)
+			listed.add(member)
+		}
+	}
+	for login := range leads {
+		listed.add(login)
+	}
+
+	teams, err := client.listTeams(ctx, orgName)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("# %s GitHub team drift\n", sigDir)
+	for _, team := range teams {
+		if !strings.HasPrefix(team.GetSlug(), sigDir+"-") {
+			continue
+		}
+		members, err := client.listTeamMembers(ctx, orgName, team.GetSlug())
+		if err != nil {
+			return err
+		}
+
+		// Teams with a counterpart must match it exactly, others may only
+		// contain people listed somewhere.
+		var missing, extra []string
+		want, ok := aliases[team.GetSlug()]
+		if team.GetSlug() == leadsTeam {
+			want, ok = leads, true
+		}
+		if ok {
+			missing, extra = want.diff(members), members.diff(want)
+		} else {
+			extra = members.diff(listed)
+		}
+		if len(missing) == 0 && len(extra) == 0 {
+			continue
+		}
+
+		fmt.Printf("\n## %s\n\n", team.GetSlug())
+		for _, login := range missing {
+			fmt.Printf("- missing @%s\n", login)
+		}
+		for _, login := range extra {
+			fmt.Printf("- unexpected @%s\n", login)
+		}
+	}
+	return nil
+}
+
+// loginSet is a set of GitHub logins, which are case-insensitive.
+type loginSet map[string]bool
+
+func (s loginSet) add(login string) {
+	s[strings.ToLower(login)] = true
+}
+
+// diff returns the logins of s that are not in other, sorted.
+func (s loginSet) diff(other loginSet) []string {
+	var logins []string
+	for login := range s {
+		if !other[login] {
+			logins = append(logins, login)
+		}
+	}
+	sort.Strings(logins)
+	return logins
+}
+
+func (c *ghClient) listTeams(ctx context.Context, org string) ([]*github.Team, error) {
+	var allTeams []*github.Team
+	opts := &github.ListOptions{PerPage: perPage}
+
+	for {
+		teams, resp, err := c.Teams.ListTeams(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+		allTeams = append(allTeams, teams...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allTeams, nil
+}
+
+func (c *ghClient) listTeamMembers(ctx context.Context, org, slug string) (loginSet, error) {
+	members := loginSet{}
+	opts := &github.TeamListTeamMembersOptions{
+		ListOptions: github.ListOptions{PerPage: perPage},
+	}
+
+	for {
+		users, resp, err := c.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			members.add(user.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return members, nil
+}
+
+// getYAMLFile reads a YAML file from the default branch of the repository into v.
+func (c *ghClient) getYAMLFile(ctx context.Context, owner, repo, path string, v interface{}) error {
+	file, _, _, err := c.Repositories.GetContents(ctx, owner, repo, path, nil)
+	if err != nil {
+		return err
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal([]byte(content), v); err != nil {
+		return fmt.Errorf("parsing %s/%s/%s: %w", owner, repo, path, err)
+	}
+	return nil
+}
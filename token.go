@@ -0,0 +1,241 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// loadTokens returns the GitHub tokens from GITHUB_TOKENS, a comma-separated
+// list, or GITHUB_TOKEN. For local runs without either, the token cached by
+// the login command or the token of an authenticated gh CLI is used.
+func loadTokens() []string {
+	var tokens []string
+	for _, token := range strings.Split(os.Getenv("GITHUB_TOKENS"), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 && os.Getenv("GITHUB_TOKEN") != "" {
+		tokens = append(tokens, os.Getenv("GITHUB_TOKEN"))
+	}
+	if len(tokens) == 0 {
+		if token := cachedToken(); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		if token := ghCLIToken(); token != "" {
+			fmt.Println("GITHUB_TOKEN is not set, using the token of the gh CLI")
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// ghCLIToken returns the token the gh CLI is authenticated with, or an empty
+// string if gh is not installed or not logged in.
+func ghCLIToken() string {
+	out, err := exec.Command("gh", "auth", "token", "--hostname", "github.com").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// tokenRotator authenticates each request with the token that has the most
+// remaining quota for the API the request is counted against, spreading the
+// load over several tokens. Requests are paced once the quota runs low, and
+// requests that exhausted the quota of their token are retried with the next
+// best one.
+type tokenRotator struct {
+	base   http.RoundTripper
+	tokens []string
+	// budget records the quotas for the other jobs sharing the tokens, nil if
+	// $API_BUDGET_FILE is not set.
+	budget *apiBudget
+
+	mu sync.Mutex
+	// quotas is the last known quota of each token by API resource, as
+	// reported by the X-RateLimit headers. Tokens without a known quota are
+	// tried first.
+	quotas []map[string]quota
+}
+
+// quota is the rate limit state of a token for an API resource.
+type quota struct {
+	remaining int
+	limit     int
+	reset     time.Time
+}
+
+const (
+	// rateLimitSlowdown is the share of the quota, in percent, below which
+	// requests are paced so that the remaining quota lasts until the reset.
+	rateLimitSlowdown = 25
+	// rateLimitReserve is the share of the quota, in percent, left untouched
+	// for the other SIG automation sharing the token.
+	rateLimitReserve = 10
+)
+
+// delay returns how long to wait before the next request, given the quota. It
+// spreads the quota above the reserve evenly over the time until the reset,
+// and waits for the reset once only the reserve is left.
+func (q quota) delay(now time.Time) time.Duration {
+	if q.limit == 0 || q.remaining*100 >= q.limit*rateLimitSlowdown || !q.reset.After(now) {
+		return 0
+	}
+	untilReset := q.reset.Sub(now)
+	available := q.remaining - q.limit*rateLimitReserve/100
+	if available <= 0 {
+		return untilReset
+	}
+	return untilReset / time.Duration(available)
+}
+
+func newTokenRotator(base http.RoundTripper, tokens []string) *tokenRotator {
+	r := &tokenRotator{base: base, tokens: tokens, budget: openAPIBudget()}
+	for range tokens {
+		r.quotas = append(r.quotas, map[string]quota{})
+	}
+	return r
+}
+
+// rateLimitResource returns the rate limit resource the request is counted against.
+func rateLimitResource(req *http.Request) string {
+	switch {
+	case req.URL.Path == "/graphql":
+		return "graphql"
+	case strings.HasPrefix(req.URL.Path, "/search/"):
+		return "search"
+	}
+	return "core"
+}
+
+// pick returns the index of the token with the most remaining quota for the
+// resource, skipping the tokens in tried.
+func (r *tokenRotator) pick(resource string, tried map[int]bool) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	best, bestRemaining := -1, -1
+	for i := range r.tokens {
+		if tried[i] {
+			continue
+		}
+		q, ok := r.quotas[i][resource]
+		if !ok {
+			return i
+		}
+		if q.remaining > bestRemaining {
+			best, bestRemaining = i, q.remaining
+		}
+	}
+	return best
+}
+
+// throttle waits before sending the request with token i when its quota for
+// the resource is running low, see quota.delay. It fails right away when the
+// wait would outlast the deadline of the request.
+func (r *tokenRotator) throttle(req *http.Request, i int, resource string) error {
+	r.mu.Lock()
+	q, ok := r.quotas[i][resource]
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	delay := q.delay(time.Now())
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < delay {
+		return fmt.Errorf("%s quota of token %d of %d exhausted, %d of %d left until %s: waiting %s would outlast the deadline of the run, see --timeout", resource, i+1, len(r.tokens), q.remaining, q.limit, q.reset.Format(time.RFC3339), delay.Round(time.Second))
+	}
+	if delay > time.Second {
+		fmt.Printf("token %d of %d has %d of %d %s quota left, waiting %s\n", i+1, len(r.tokens), q.remaining, q.limit, resource, delay.Round(time.Second))
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *tokenRotator) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(r.tokens) == 0 {
+		return r.base.RoundTrip(req)
+	}
+
+	resource := rateLimitResource(req)
+	tried := map[int]bool{}
+	for {
+		i := r.pick(resource, tried)
+		tried[i] = true
+		if err := r.throttle(req, i, resource); err != nil {
+			return nil, err
+		}
+
+		attempt := req.Clone(req.Context())
+		attempt.Header.Set("Authorization", "Bearer "+r.tokens[i])
+		if req.Body != nil && req.GetBody != nil && len(tried) > 1 {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+
+		resp, err := r.base.RoundTrip(attempt)
+		if err != nil {
+			return nil, err
+		}
+		remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+		if err != nil {
+			return resp, nil
+		}
+		if res := resp.Header.Get("X-RateLimit-Resource"); res != "" {
+			resource = res
+		}
+		q := quota{remaining: remaining}
+		q.limit, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			q.reset = time.Unix(reset, 0)
+		}
+		r.mu.Lock()
+		r.quotas[i][resource] = q
+		r.mu.Unlock()
+		r.budget.record(r.tokens[i], resource, q)
+
+		exhausted := remaining == 0 && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests)
+		retryable := req.Body == nil || req.GetBody != nil
+		if !exhausted || !retryable || len(tried) == len(r.tokens) {
+			return resp, nil
+		}
+		fmt.Printf("token %d of %d exhausted its %s quota, retrying with another token\n", i+1, len(r.tokens), resource)
+		resp.Body.Close()
+	}
+}
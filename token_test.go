@@ -0,0 +1,89 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuotaDelay(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(30 * time.Minute)
+	for _, tc := range []struct {
+		name  string
+		quota quota
+		want  time.Duration
+	}{{
+		name: "unknown limit",
+		want: 0,
+	}, {
+		name:  "plenty left",
+		quota: quota{remaining: 4000, limit: 5000, reset: reset},
+		want:  0,
+	}, {
+		name:  "at the slowdown threshold",
+		quota: quota{remaining: 1250, limit: 5000, reset: reset},
+		want:  0,
+	}, {
+		// 749 requests above the reserve of 500 spread over 30 minutes.
+		name:  "running low",
+		quota: quota{remaining: 1250 - 1, limit: 5000, reset: reset},
+		want:  30 * time.Minute / 749,
+	}, {
+		name:  "only the reserve left",
+		quota: quota{remaining: 500, limit: 5000, reset: reset},
+		want:  30 * time.Minute,
+	}, {
+		name:  "exhausted",
+		quota: quota{remaining: 0, limit: 5000, reset: reset},
+		want:  30 * time.Minute,
+	}, {
+		name:  "reset passed",
+		quota: quota{remaining: 0, limit: 5000, reset: now.Add(-time.Second)},
+		want:  0,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.quota.delay(now); got != tc.want {
+				t.Errorf("delay() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestThrottleDeadline(t *testing.T) {
+	r := newTokenRotator(http.DefaultTransport, []string{"token"})
+	r.quotas[0]["core"] = quota{remaining: 0, limit: 5000, reset: time.Now().Add(time.Hour)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	err = r.throttle(req, 0, "core")
+	if err == nil || !strings.Contains(err.Error(), "quota of token 1 of 1 exhausted") {
+		t.Errorf("throttle() = %v, want a quota exhausted error", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("throttle() waited %s before failing", elapsed)
+	}
+}
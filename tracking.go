@@ -0,0 +1,176 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// runTrackingIssue creates or updates the profile's tracking issue with a task
+// list of all untriaged board items, for contributors without project access.
+func runTrackingIssue(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("tracking-issue", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	if prof.TrackingIssue == nil {
+		return fmt.Errorf("profile %q has no trackingIssue configured", common.profileName)
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	var untriaged []*projectItem
+	for _, item := range items {
+		if item.URL == "" || item.State != "OPEN" || !prof.isUntriaged(item.Status) {
+			continue
+		}
+		if _, active := snoozed(item.values, time.Now()); active {
+			continue
+		}
+		untriaged = append(untriaged, item)
+	}
+
+	_, err = client.upsertIssue(ctx, *prof.TrackingIssue, trackingIssueBody(project, untriaged))
+	return err
+}
+
+func trackingIssueBody(p *project, items []*projectItem) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This issue is maintained by [sig-auth-tools](https://github.com/kubernetes-sigs/sig-auth-tools) "+
+		"and lists the %d items waiting for triage on the %q project board.\n\n", len(items), p.Title)
+	for _, item := range items {
+		fmt.Fprintf(&b, "- [ ] %s#%d\n", item.Repository, item.Number)
+	}
+	return b.String()
+}
+
+// postRunSummary comments the run counts and the newly added items on the
+// run log issue, creating the issue if needed.
+func (c *ghClient) postRunSummary(ctx context.Context, ref issueRef, p *project, stats *runStats) error {
+	owner, repo, err := ref.split()
+	if err != nil {
+		return err
+	}
+
+	issue, err := c.findIssue(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		issue, _, err = c.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+			Title: github.String(ref.Title),
+			Body: github.String(fmt.Sprintf("This issue is a log of the [sig-auth-tools](https://github.com/kubernetes-sigs/sig-auth-tools) "+
+				"runs syncing the %q project board. A summary is posted as a comment after each run.", p.Title)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Synced the %q project board in %s: %d items synced, %d added, %d status changes.\n",
+		p.Title, time.Since(stats.start).Round(time.Second), stats.synced, len(stats.added), stats.moved)
+	if len(stats.added) > 0 {
+		b.WriteString("\nNew items:\n\n")
+		for _, url := range stats.added {
+			fmt.Fprintf(&b, "- %s\n", url)
+		}
+	}
+
+	_, _, err = c.Issues.CreateComment(ctx, owner, repo, issue.GetNumber(), &github.IssueComment{
+		Body: github.String(b.String()),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("posted run summary to %s\n", issue.GetHTMLURL())
+	return nil
+}
+
+// upsertIssue sets the body of the open issue with the given title, creating
+// the issue if it does not exist, and returns it.
+func (c *ghClient) upsertIssue(ctx context.Context, ref issueRef, body string) (*github.Issue, error) {
+	owner, repo, err := ref.split()
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := c.findIssue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if issue == nil {
+		issue, _, err = c.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+			Title: github.String(ref.Title),
+			Body:  github.String(body),
+		})
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("created issue %s\n", issue.GetHTMLURL())
+		return issue, nil
+	}
+
+	issue, _, err = c.Issues.Edit(ctx, owner, repo, issue.GetNumber(), &github.IssueRequest{
+		Body: github.String(body),
+	})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("updated issue %s\n", issue.GetHTMLURL())
+	return issue, nil
+}
+
+// findIssue returns the open issue with the exact title, or nil if there is none.
+func (c *ghClient) findIssue(ctx context.Context, ref issueRef) (*github.Issue, error) {
+	query := fmt.Sprintf("repo:%s is:issue is:open in:title %q", ref.Repo, ref.Title)
+	result, _, err := c.Search.Issues(ctx, query, &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: perPage},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range result.Issues {
+		if issue.GetTitle() == ref.Title {
+			return issue, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,277 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+const (
+	// priorityFieldName is the name of the single select field holding the
+	// item priority, set by the triage command.
+	priorityFieldName = "Priority"
+	// triageBodyLines is the number of lines of the item body shown.
+	triageBodyLines = 20
+)
+
+// priorityKeys are the keys selecting the priority options, shifted digits
+// so that they sit above the status keys.
+var priorityKeys = []string{"!", "@", "#", "$", "%", "^", "&", "*", "("}
+
+// runTriage pages through the untriaged items of the board in a terminal UI,
+// setting their status, priority and assignee with single keystrokes.
+func runTriage(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("triage", flag.ExitOnError)
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	m := &triageModel{
+		ctx:     ctx,
+		client:  client,
+		project: project,
+		login:   user.GetLogin(),
+		bodies:  map[string]string{},
+	}
+	for _, item := range items {
+		if _, active := snoozed(item.values, time.Now()); active || item.State != "OPEN" || !prof.isUntriaged(item.Status) {
+			continue
+		}
+		if item.Type == githubql.ProjectV2ItemTypeIssue || item.Type == githubql.ProjectV2ItemTypePullRequest {
+			m.items = append(m.items, item)
+		}
+	}
+	if len(m.items) == 0 {
+		fmt.Printf("no untriaged items on %q\n", project.Title)
+		return nil
+	}
+	if field, ok := project.fields[statusFieldName]; ok {
+		m.statuses = field.optionNames
+	}
+	if field, ok := project.fields[priorityFieldName]; ok {
+		m.priorities = field.optionNames
+	}
+
+	_, err = tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	return err
+}
+
+// triageModel is the state of the triage UI.
+type triageModel struct {
+	ctx     context.Context
+	client  *ghClient
+	project *project
+	// login is the authenticated user, whom items are assigned to.
+	login string
+
+	items []*projectItem
+	index int
+	// bodies are the descriptions of the items shown so far, by URL.
+	bodies map[string]string
+	// statuses and priorities are the options of the Status and Priority
+	// fields, selected by the digit and shifted digit keys.
+	statuses   []string
+	priorities []string
+	// message is the outcome of the last action.
+	message string
+}
+
+// triageBodyMsg is the description of an item, once fetched.
+type triageBodyMsg struct {
+	url  string
+	body string
+	err  error
+}
+
+// triageResultMsg is the outcome of an action on the item at index.
+type triageResultMsg struct {
+	index   int
+	item    *projectItem
+	message string
+	err     error
+}
+
+func (m *triageModel) Init() tea.Cmd {
+	return m.fetchBody()
+}
+
+func (m *triageModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case triageBodyMsg:
+		if msg.err != nil {
+			m.bodies[msg.url] = fmt.Sprintf("failed to get the description: %v", msg.err)
+		} else {
+			m.bodies[msg.url] = msg.body
+		}
+	case triageResultMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("failed: %v", msg.err)
+			return m, nil
+		}
+		m.items[msg.index] = msg.item
+		m.message = msg.message
+	case tea.KeyMsg:
+		return m.handleKey(msg.String())
+	}
+	return m, nil
+}
+
+func (m *triageModel) handleKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "n", "j", "right", "enter", "tab":
+		if m.index < len(m.items)-1 {
+			m.index++
+		}
+		m.message = ""
+		return m, m.fetchBody()
+	case "p", "k", "left", "shift+tab":
+		if m.index > 0 {
+			m.index--
+		}
+		m.message = ""
+		return m, m.fetchBody()
+	case "a":
+		return m, m.assign()
+	}
+	for i, status := range m.statuses {
+		if i < 9 && key == fmt.Sprint(i+1) {
+			return m, m.setField(statusFieldName, status)
+		}
+	}
+	for i, priority := range m.priorities {
+		if i < len(priorityKeys) && key == priorityKeys[i] {
+			return m, m.setField(priorityFieldName, priority)
+		}
+	}
+	return m, nil
+}
+
+// fetchBody fetches the description of the current item unless it is known.
+func (m *triageModel) fetchBody() tea.Cmd {
+	item := m.items[m.index]
+	if _, ok := m.bodies[item.URL]; ok {
+		return nil
+	}
+	return func() tea.Msg {
+		owner, repo, err := splitRepo(item.Repository)
+		if err != nil {
+			return triageBodyMsg{url: item.URL, err: err}
+		}
+		issue, _, err := m.client.Issues.Get(m.ctx, owner, repo, item.Number)
+		if err != nil {
+			return triageBodyMsg{url: item.URL, err: err}
+		}
+		return triageBodyMsg{url: item.URL, body: htmlCommentRE.ReplaceAllString(issue.GetBody(), "")}
+	}
+}
+
+// setField sets the field of the current item. The mutation runs on a copy of
+// the item, which replaces it once done, so that the view never reads an item
+// being updated.
+func (m *triageModel) setField(fieldName, value string) tea.Cmd {
+	index := m.index
+	item := *m.items[index]
+	item.values = make(map[string]string, len(m.items[index].values))
+	for k, v := range m.items[index].values {
+		item.values[k] = v
+	}
+	m.message = fmt.Sprintf("setting %s to %s...", fieldName, value)
+	return func() tea.Msg {
+		err := m.client.setSingleSelectField(m.ctx, m.project, &item, fieldName, value)
+		return triageResultMsg{index: index, item: &item, message: fmt.Sprintf("set %s to %s", fieldName, value), err: err}
+	}
+}
+
+// assign assigns the current item to the authenticated user.
+func (m *triageModel) assign() tea.Cmd {
+	index := m.index
+	item := m.items[index]
+	m.message = fmt.Sprintf("assigning @%s...", m.login)
+	return func() tea.Msg {
+		owner, repo, err := splitRepo(item.Repository)
+		if err != nil {
+			return triageResultMsg{err: err}
+		}
+		_, _, err = m.client.Issues.AddAssignees(m.ctx, owner, repo, item.Number, []string{m.login})
+		return triageResultMsg{index: index, item: item, message: fmt.Sprintf("assigned @%s", m.login), err: err}
+	}
+}
+
+func (m *triageModel) View() string {
+	item := m.items[m.index]
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%d/%d] %s#%d %s\n", m.index+1, len(m.items), item.Repository, item.Number, item.Title)
+	fmt.Fprintf(&b, "%s\n\n", item.URL)
+	fmt.Fprintf(&b, "Status: %s   Priority: %s\n", item.Status, item.values[priorityFieldName])
+	fmt.Fprintf(&b, "Labels: %s\n\n", strings.Join(item.Labels, ", "))
+
+	body, ok := m.bodies[item.URL]
+	if !ok {
+		body = "loading..."
+	}
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if len(lines) > triageBodyLines {
+		lines = append(lines[:triageBodyLines], "...")
+	}
+	fmt.Fprintf(&b, "%s\n\n", strings.Join(lines, "\n"))
+
+	for i, status := range m.statuses {
+		if i < 9 {
+			fmt.Fprintf(&b, "%d %s  ", i+1, status)
+		}
+	}
+	b.WriteString("\n")
+	for i, priority := range m.priorities {
+		if i < len(priorityKeys) {
+			fmt.Fprintf(&b, "%s %s  ", priorityKeys[i], priority)
+		}
+	}
+	b.WriteString("\na assign me  n next  p previous  q quit\n")
+	if m.message != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.message)
+	}
+	return b.String()
+}
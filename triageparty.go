@@ -0,0 +1,194 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// triagePartyConfig is a triage-party configuration file.
+// xref: https://github.com/google/triage-party/blob/main/docs/config.md
+type triagePartyConfig struct {
+	Settings    triagePartySettings        `json:"settings"`
+	Collections []triagePartyCollection    `json:"collections"`
+	Rules       map[string]triagePartyRule `json:"rules"`
+}
+
+type triagePartySettings struct {
+	Name  string   `json:"name"`
+	Repos []string `json:"repos"`
+}
+
+type triagePartyCollection struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Rules []string `json:"rules"`
+}
+
+type triagePartyRule struct {
+	Name string `json:"name"`
+	// Type is either issue or pull_request.
+	Type string `json:"type"`
+	// Repos overrides the repositories of the settings.
+	Repos   []string            `json:"repos,omitempty"`
+	Filters []map[string]string `json:"filters"`
+}
+
+// slugRE matches the characters replaced in triage-party IDs.
+var slugRE = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(s string) string {
+	return strings.Trim(slugRE.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+func runTriageParty(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("triage-party", flag.ExitOnError)
+	common.register(fs)
+	output := fs.String("output", "", "file to write the triage-party config to, defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	sourceRepos := make([][]string, len(prof.Sources))
+	for i, src := range prof.Sources {
+		repos, err := client.listSourceRepos(ctx, src)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if src.includesRepo(repo) {
+				sourceRepos[i] = append(sourceRepos[i], repo.GetFullName())
+			}
+		}
+	}
+
+	data, err := yaml.Marshal(newTriagePartyConfig(common.profileName, prof, sourceRepos))
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*output, data, 0644)
+}
+
+// newTriagePartyConfig renders the profile as a triage-party config with one
+// collection per initial status, listing the untriaged items that would be in
+// that status on the board. sourceRepos are the repositories of each source.
+func newTriagePartyConfig(name string, prof profile, sourceRepos [][]string) *triagePartyConfig {
+	cfg := &triagePartyConfig{
+		Settings: triagePartySettings{Name: name},
+		Rules:    map[string]triagePartyRule{},
+	}
+	collections := map[string]*triagePartyCollection{}
+	addRule := func(id, status, itemType string, repos []string, labels []string) {
+		if status == "" || (prof.PullRequestsOnly && itemType == "issue") {
+			return
+		}
+		id = slug(id + "-" + itemType)
+		rule := triagePartyRule{
+			Name:  fmt.Sprintf("%s (%s)", status, strings.ReplaceAll(itemType, "_", " ")),
+			Type:  itemType,
+			Repos: repos,
+		}
+		for _, label := range labels {
+			rule.Filters = append(rule.Filters, map[string]string{"label": label})
+		}
+		// Accepted items are triaged, whatever their status on the board.
+		rule.Filters = append(rule.Filters, map[string]string{"label": "!triage/accepted"})
+		cfg.Rules[id] = rule
+
+		c, ok := collections[status]
+		if !ok {
+			c = &triagePartyCollection{ID: slug(status), Name: status}
+			collections[status] = c
+		}
+		c.Rules = append(c.Rules, id)
+	}
+
+	repoURLs := func(repos []string) []string {
+		var urls []string
+		for _, repo := range repos {
+			urls = append(urls, "https://github.com/"+repo)
+		}
+		return urls
+	}
+
+	// Label and repository routing take precedence over the source status, so
+	// those items are excluded from the source rules.
+	var routedLabels []string
+	for label := range prof.LabelStatuses {
+		routedLabels = append(routedLabels, label)
+	}
+	sort.Strings(routedLabels)
+
+	var all []string
+	for i, src := range prof.Sources {
+		var repos []string
+		for _, repo := range sourceRepos[i] {
+			if _, ok := prof.RepoStatuses[repo]; !ok {
+				repos = append(repos, repo)
+			}
+		}
+		all = append(all, repos...)
+
+		labels := append([]string{}, src.Labels...)
+		for _, label := range routedLabels {
+			labels = append(labels, "!"+label)
+		}
+		addRule(src.name(), src.IssueStatus, "issue", repoURLs(repos), labels)
+		addRule(src.name(), src.PullRequestStatus, "pull_request", repoURLs(repos), labels)
+
+		for _, label := range routedLabels {
+			labels := append(append([]string{}, src.Labels...), label)
+			addRule(src.name()+"-"+label, prof.LabelStatuses[label], "issue", repoURLs(repos), labels)
+			addRule(src.name()+"-"+label, prof.LabelStatuses[label], "pull_request", repoURLs(repos), labels)
+		}
+
+		for _, repo := range sourceRepos[i] {
+			if status, ok := prof.RepoStatuses[repo]; ok {
+				all = append(all, repo)
+				addRule(repo, status, "issue", repoURLs([]string{repo}), src.Labels)
+				addRule(repo, status, "pull_request", repoURLs([]string{repo}), src.Labels)
+			}
+		}
+	}
+
+	sort.Strings(all)
+	cfg.Settings.Repos = repoURLs(all)
+	for _, c := range collections {
+		cfg.Collections = append(cfg.Collections, *c)
+	}
+	sort.Slice(cfg.Collections, func(i, j int) bool { return cfg.Collections[i].ID < cfg.Collections[j].ID })
+	return cfg
+}
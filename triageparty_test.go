@@ -0,0 +1,106 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewTriagePartyConfig(t *testing.T) {
+	src := source{Org: "kubernetes", Labels: []string{"sig/auth"}, IssueStatus: statusNeedsTriage, PullRequestStatus: statusPRsNeedsReview}
+	for _, tc := range []struct {
+		name        string
+		prof        profile
+		sourceRepos [][]string
+		collections []triagePartyCollection
+		repos       []string
+		// rule is the ID of a rule expected to have ruleRepos and filters.
+		rule      string
+		ruleRepos []string
+		filters   []map[string]string
+	}{{
+		name:        "source statuses",
+		prof:        profile{Sources: []source{src}},
+		sourceRepos: [][]string{{"kubernetes/kubernetes"}},
+		collections: []triagePartyCollection{
+			{ID: "needs-triage", Name: statusNeedsTriage, Rules: []string{"kubernetes-issue"}},
+			{ID: "prs-needs-review", Name: statusPRsNeedsReview, Rules: []string{"kubernetes-pull-request"}},
+		},
+		repos:     []string{"https://github.com/kubernetes/kubernetes"},
+		rule:      "kubernetes-issue",
+		ruleRepos: []string{"https://github.com/kubernetes/kubernetes"},
+		filters:   []map[string]string{{"label": "sig/auth"}, {"label": "!triage/accepted"}},
+	}, {
+		name:        "pull requests only",
+		prof:        profile{Sources: []source{src}, PullRequestsOnly: true},
+		sourceRepos: [][]string{{"kubernetes/kubernetes"}},
+		collections: []triagePartyCollection{
+			{ID: "prs-needs-review", Name: statusPRsNeedsReview, Rules: []string{"kubernetes-pull-request"}},
+		},
+		repos: []string{"https://github.com/kubernetes/kubernetes"},
+	}, {
+		name:        "label routing",
+		prof:        profile{Sources: []source{src}, LabelStatuses: map[string]string{"wg/policy": "WG Policy - Needs Triage"}},
+		sourceRepos: [][]string{{"kubernetes/kubernetes"}},
+		collections: []triagePartyCollection{
+			{ID: "needs-triage", Name: statusNeedsTriage, Rules: []string{"kubernetes-issue"}},
+			{ID: "prs-needs-review", Name: statusPRsNeedsReview, Rules: []string{"kubernetes-pull-request"}},
+			{ID: "wg-policy-needs-triage", Name: "WG Policy - Needs Triage", Rules: []string{"kubernetes-wg-policy-issue", "kubernetes-wg-policy-pull-request"}},
+		},
+		repos:     []string{"https://github.com/kubernetes/kubernetes"},
+		rule:      "kubernetes-issue",
+		ruleRepos: []string{"https://github.com/kubernetes/kubernetes"},
+		filters:   []map[string]string{{"label": "sig/auth"}, {"label": "!wg/policy"}, {"label": "!triage/accepted"}},
+	}, {
+		name:        "repository routing",
+		prof:        profile{Sources: []source{src}, RepoStatuses: map[string]string{"kubernetes/enhancements": "KEPs"}},
+		sourceRepos: [][]string{{"kubernetes/kubernetes", "kubernetes/enhancements"}},
+		collections: []triagePartyCollection{
+			{ID: "keps", Name: "KEPs", Rules: []string{"kubernetes-enhancements-issue", "kubernetes-enhancements-pull-request"}},
+			{ID: "needs-triage", Name: statusNeedsTriage, Rules: []string{"kubernetes-issue"}},
+			{ID: "prs-needs-review", Name: statusPRsNeedsReview, Rules: []string{"kubernetes-pull-request"}},
+		},
+		repos:     []string{"https://github.com/kubernetes/enhancements", "https://github.com/kubernetes/kubernetes"},
+		rule:      "kubernetes-enhancements-issue",
+		ruleRepos: []string{"https://github.com/kubernetes/enhancements"},
+		filters:   []map[string]string{{"label": "sig/auth"}, {"label": "!triage/accepted"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTriagePartyConfig("triage", tc.prof, tc.sourceRepos)
+			if !reflect.DeepEqual(cfg.Collections, tc.collections) {
+				t.Errorf("collections = %+v, want %+v", cfg.Collections, tc.collections)
+			}
+			if !reflect.DeepEqual(cfg.Settings.Repos, tc.repos) {
+				t.Errorf("repos = %q, want %q", cfg.Settings.Repos, tc.repos)
+			}
+			if tc.rule == "" {
+				return
+			}
+			rule, ok := cfg.Rules[tc.rule]
+			if !ok {
+				t.Fatalf("no rule %q", tc.rule)
+			}
+			if !reflect.DeepEqual(rule.Repos, tc.ruleRepos) {
+				t.Errorf("rule %q repos = %q, want %q", tc.rule, rule.Repos, tc.ruleRepos)
+			}
+			if !reflect.DeepEqual(rule.Filters, tc.filters) {
+				t.Errorf("rule %q filters = %v, want %v", tc.rule, rule.Filters, tc.filters)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// Categories of drift between GitHub and the board.
+const (
+	// driftExtraneous are board items the profile does not import.
+	driftExtraneous = "extraneous"
+	// driftMissing are items of the sources missing from the board.
+	driftMissing = "missing"
+	// driftStatus are tool-managed items whose status contradicts their
+	// labels or state.
+	driftStatus = "status"
+	// driftField are fields that differ from what sync would set.
+	driftField = "field"
+)
+
+// driftCategories are the drift categories in report order.
+var driftCategories = []string{driftExtraneous, driftMissing, driftStatus, driftField}
+
+// drift is an inconsistency between an item on GitHub and the board.
+type drift struct {
+	Category string
+	URL      string
+	Detail   string
+
+	// Entry is the board mutation fixing the inconsistency. Missing items are
+	// added as by sync, which also sets their status and fields.
+	Entry auditEntry
+
+	// src and issue are those of driftMissing items, item is the board item
+	// of the others.
+	src   source
+	issue *github.Issue
+	item  *projectItem
+}
+
+// runValidate reports the drift between GitHub and the board, and fails if
+// there is any, so that it can gate the automation. Only the categories given
+// by --repair are fixed.
+func runValidate(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	common.register(fs)
+	var repair stringList
+	fs.Var(&repair, "repair", "comma-separated list of drift categories to fix: "+strings.Join(driftCategories, ", ")+", or all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	repairs := stringSet(repair)
+	if repairs["all"] {
+		repairs = stringSet(driftCategories)
+	}
+	for category := range repairs {
+		if !stringSet(driftCategories)[category] {
+			return fmt.Errorf("invalid --repair category %q, must be one of: %s, all", category, strings.Join(driftCategories, ", "))
+		}
+	}
+
+	s, err := newValidateSyncer(ctx, tokens, common)
+	if err != nil {
+		return err
+	}
+	drifts, err := s.findDrift(ctx)
+	if err != nil {
+		return err
+	}
+
+	byCategory := map[string][]drift{}
+	for _, d := range drifts {
+		byCategory[d.Category] = append(byCategory[d.Category], d)
+	}
+	fmt.Printf("# Drift between GitHub and %q\n", s.project.Title)
+	for _, category := range driftCategories {
+		fmt.Printf("\n## %s (%d)\n\n", category, len(byCategory[category]))
+		for _, d := range byCategory[category] {
+			fmt.Printf("- %s %s\n", d.URL, d.Detail)
+		}
+	}
+
+	unrepaired := 0
+	for _, d := range drifts {
+		if !repairs[d.Category] {
+			unrepaired++
+			continue
+		}
+		if err := s.repairDrift(ctx, d); err != nil {
+			return fmt.Errorf("repairing %s: %w", d.URL, err)
+		}
+	}
+	if unrepaired > 0 {
+		return fmt.Errorf("found %d inconsistencies that were not repaired", unrepaired)
+	}
+	return nil
+}
+
+// newValidateSyncer returns a syncer of the profile with the filter of a sync
+// without flags, for comparing the board with what sync would do.
+func newValidateSyncer(ctx context.Context, tokens []string, common commonFlags) (*syncer, error) {
+	cfg, prof, err := common.load()
+	if err != nil {
+		return nil, err
+	}
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return nil, err
+	}
+	return &syncer{
+		stats:   &runStats{start: time.Now()},
+		client:  client,
+		project: project,
+		profile: prof,
+		filter: itemFilter{
+			pullRequestsOnly: prof.PullRequestsOnly,
+			excludedAuthors:  stringSet(cfg.BotAuthors),
+			exemptLabels:     cfg.ExemptLabels,
+		},
+		botAuthors: stringSet(cfg.BotAuthors),
+		schedule:   cfg.ReleaseSchedule,
+	}, nil
+}
+
+// repairDrift makes the minimal change fixing the inconsistency: extraneous
+// items are removed, missing ones synced as by sync, and statuses and fields
+// set to what sync would set them to.
+func (s *syncer) repairDrift(ctx context.Context, d drift) error {
+	if d.Entry.Action == auditAdd {
+		fmt.Printf("adding [%d] %s to project\n", d.issue.GetNumber(), d.issue.GetTitle())
+		return s.addAndUpdateProjectItem(ctx, d.src, d.issue)
+	}
+	fmt.Println(d.Entry.describe())
+	return s.client.applyEntry(ctx, s.project, d.item, d.Entry)
+}
+
+// findDrift compares the board with the items of the profile's sources. Field
+// drift is found by syncing the fields of each item into a plan, so the
+// client's plan is replaced.
+func (s *syncer) findDrift(ctx context.Context) ([]drift, error) {
+	scope, err := s.client.loadBoardScope(ctx, s.profile)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.client.listProjectItems(ctx, s.project)
+	if err != nil {
+		return nil, err
+	}
+	onBoard := itemsByContentID(items)
+
+	var drifts []drift
+	exempt := stringSet(s.filter.exemptLabels)
+	for _, item := range items {
+		switch {
+		case scope.isOrphan(item):
+			drifts = append(drifts, drift{Category: driftExtraneous, URL: item.URL, Detail: "is not imported by any source", Entry: s.deleteEntry(item), item: item})
+		default:
+			for _, label := range item.Labels {
+				if exempt[label] {
+					drifts = append(drifts, drift{Category: driftExtraneous, URL: item.URL, Detail: "is labeled " + label, Entry: s.deleteEntry(item), item: item})
+					break
+				}
+			}
+		}
+	}
+
+	s.client.plan = &mutationPlan{}
+	defer func() { s.client.plan = nil }()
+	for _, src := range s.profile.Sources {
+		repos, err := s.client.listSourceRepos(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			owner := repo.GetOwner().GetLogin()
+			if !src.includesRepo(repo) || !s.filter.includesRepo(owner, *repo.Name) {
+				continue
+			}
+			issues, err := s.client.listIssuesAndPullRequests(ctx, owner, *repo.Name, src.Labels...)
+			if err != nil {
+				return nil, err
+			}
+			for _, issue := range s.filter.forSource(src).filterItems(issues) {
+				item := onBoard[issue.GetNodeID()]
+				if item == nil {
+					entry := auditEntry{Action: auditAdd, Project: s.project.Title, Content: issue.GetHTMLURL()}
+					drifts = append(drifts, drift{Category: driftMissing, URL: issue.GetHTMLURL(), Detail: "is not on the board", Entry: entry, src: src, issue: issue})
+					continue
+				}
+
+				var pr *pullRequest
+				if issue.IsPullRequest() {
+					pr, err = s.client.getPullRequest(ctx, issue.GetNodeID())
+					if err != nil {
+						return nil, err
+					}
+				}
+				if set, _ := snoozed(item.values, time.Now()); !set && s.profile.isReconcilable(item.Status) {
+					if want := s.managedStatus(src, issue, pr); want != item.Status {
+						entry := auditEntry{
+							Action:  auditUpdate,
+							Project: s.project.Title,
+							ItemID:  fmt.Sprint(item.ID),
+							Content: item.URL,
+							Field:   statusFieldName,
+							Before:  item.Status,
+							After:   want,
+						}
+						drifts = append(drifts, drift{Category: driftStatus, URL: item.URL, Detail: fmt.Sprintf("is in %q instead of %q", item.Status, want), Entry: entry, item: item})
+					}
+				}
+
+				planned := len(s.client.plan.Entries)
+				if err := s.syncFields(ctx, src, item, issue, pr); err != nil {
+					return nil, err
+				}
+				for _, entry := range s.client.plan.Entries[planned:] {
+					drifts = append(drifts, drift{Category: driftField, URL: item.URL, Detail: fmt.Sprintf("%s is %q instead of %q", entry.Field, entry.Before, entry.After), Entry: entry, item: item})
+				}
+			}
+		}
+	}
+	return drifts, nil
+}
+
+func (s *syncer) deleteEntry(item *projectItem) auditEntry {
+	return auditEntry{Action: auditDelete, Project: s.project.Title, ItemID: fmt.Sprint(item.ID), Content: item.URL}
+}
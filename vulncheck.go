@@ -0,0 +1,207 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// vulnFinding is a vulnerability govulncheck found in a subproject.
+type vulnFinding struct {
+	ID      string
+	Summary string
+	Module  string
+	Version string
+	// FixedVersion is the first version of Module without the vulnerability.
+	FixedVersion string
+	// Called means vulnerable code is reachable from the subproject, making
+	// the finding actionable.
+	Called bool
+}
+
+// govulncheckMessage is a message of the JSON stream of govulncheck -json.
+// xref: https://pkg.go.dev/golang.org/x/vuln/cmd/govulncheck#hdr-JSON_Output
+type govulncheckMessage struct {
+	OSV *struct {
+		ID      string `json:"id"`
+		Summary string `json:"summary"`
+	} `json:"osv"`
+	Finding *struct {
+		OSV          string `json:"osv"`
+		FixedVersion string `json:"fixed_version"`
+		Trace        []struct {
+			Module   string `json:"module"`
+			Version  string `json:"version"`
+			Function string `json:"function"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// runVulncheck runs govulncheck on a shallow clone of each subproject and
+// prints a consolidated report. Actionable findings can be filed as issues and
+// added to the board.
+func runVulncheck(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("vulncheck", flag.ExitOnError)
+	common.register(fs)
+	govulncheck := fs.String("govulncheck", "govulncheck", "path to the govulncheck binary")
+	openIssues := fs.Bool("open-issues", false, "open an issue for each actionable finding and add it to the board")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, tokens)
+	repos, err := client.listSubprojectRepos(ctx, prof)
+	if err != nil {
+		return err
+	}
+	var project *project
+	if *openIssues {
+		project, err = client.getProject(ctx, orgName, prof.Project)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("# Subproject vulnerabilities")
+	for _, repo := range repos {
+		findings, err := scanRepo(ctx, *govulncheck, repo)
+		if err != nil {
+			return fmt.Errorf("scanning %s: %w", repo.GetFullName(), err)
+		}
+		if len(findings) == 0 {
+			continue
+		}
+
+		fmt.Printf("\n## %s\n\n", repo.GetFullName())
+		for _, f := range findings {
+			reachability := "imported"
+			if f.Called {
+				reachability = "called"
+			}
+			fmt.Printf("- [%s](https://pkg.go.dev/vuln/%s) %s: %s@%s, fixed in %s (%s)\n", f.ID, f.ID, f.Summary, f.Module, f.Version, f.FixedVersion, reachability)
+			if !*openIssues || !f.Called {
+				continue
+			}
+			if err := client.fileFinding(ctx, project, repo, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fileFinding opens or updates an issue for the finding in the repository and
+// adds it to the board.
+func (c *ghClient) fileFinding(ctx context.Context, p *project, repo *github.Repository, f vulnFinding) error {
+	ref := issueRef{Repo: repo.GetFullName(), Title: fmt.Sprintf("Fix %s in %s", f.ID, f.Module)}
+	body := fmt.Sprintf("govulncheck reports that vulnerable code of %s@%s is called:\n\n> %s\n\nUpgrade to %s or later. See https://pkg.go.dev/vuln/%s for details.\n", f.Module, f.Version, f.Summary, f.FixedVersion, f.ID)
+	issue, err := c.upsertIssue(ctx, ref, body)
+	if err != nil {
+		return err
+	}
+	_, err = c.addProjectV2ItemById(ctx, p, issue.GetNodeID())
+	return err
+}
+
+// scanRepo runs govulncheck on a shallow clone of the default branch of the
+// repository.
+func scanRepo(ctx context.Context, govulncheck string, repo *github.Repository) ([]vulnFinding, error) {
+	dir, err := os.MkdirTemp("", "vulncheck-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	clone := exec.CommandContext(ctx, "git", "clone", "--quiet", "--depth=1", repo.GetCloneURL(), dir)
+	if out, err := clone.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git clone: %w: %s", err, out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, govulncheck, "-json", "./...")
+	cmd.Dir, cmd.Stdout, cmd.Stderr = dir, &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("govulncheck: %w: %s", err, stderr.Bytes())
+	}
+	return parseGovulncheck(&stdout)
+}
+
+// parseGovulncheck returns one finding per vulnerability in the JSON stream,
+// marked as called if any of its findings has a function in its trace.
+func parseGovulncheck(r io.Reader) ([]vulnFinding, error) {
+	summaries := map[string]string{}
+	findings := map[string]*vulnFinding{}
+	dec := json.NewDecoder(r)
+	for {
+		var msg govulncheckMessage
+		err := dec.Decode(&msg)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case msg.OSV != nil:
+			summaries[msg.OSV.ID] = msg.OSV.Summary
+		case msg.Finding != nil && len(msg.Finding.Trace) > 0:
+			// The first frame is the vulnerable module.
+			frame := msg.Finding.Trace[0]
+			f, ok := findings[msg.Finding.OSV]
+			if !ok {
+				f = &vulnFinding{
+					ID:           msg.Finding.OSV,
+					Module:       frame.Module,
+					Version:      frame.Version,
+					FixedVersion: msg.Finding.FixedVersion,
+				}
+				findings[msg.Finding.OSV] = f
+			}
+			f.Called = f.Called || frame.Function != ""
+		}
+	}
+
+	var list []vulnFinding
+	for id, f := range findings {
+		f.Summary = summaries[id]
+		list = append(list, *f)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGovulncheck(t *testing.T) {
+	for _, tc := range []struct {
+		name, stream string
+		want         []vulnFinding
+		wantErr      bool
+	}{{
+		name:   "no findings",
+		stream: `{"config": {"scanner_name": "govulncheck"}}`,
+	}, {
+		name: "imported only",
+		stream: `{"osv": {"id": "GO-2023-1571", "summary": "Denial of service via crafted HTTP/2 stream"}}
+{"finding": {"osv": "GO-2023-1571", "fixed_version": "v0.7.0", "trace": [{"module": "golang.org/x/net", "version": "v0.5.0"}]}}`,
+		want: []vulnFinding{{ID: "GO-2023-1571", Summary: "Denial of service via crafted HTTP/2 stream", Module: "golang.org/x/net", Version: "v0.5.0", FixedVersion: "v0.7.0"}},
+	}, {
+		name: "called by any finding",
+		stream: `{"finding": {"osv": "GO-2023-1571", "fixed_version": "v0.7.0", "trace": [{"module": "golang.org/x/net", "version": "v0.5.0"}]}}
+{"finding": {"osv": "GO-2023-1571", "fixed_version": "v0.7.0", "trace": [{"module": "golang.org/x/net", "version": "v0.5.0", "function": "ServeConn"}, {"module": "example.com/app"}]}}
+{"osv": {"id": "GO-2023-1571", "summary": "Denial of service via crafted HTTP/2 stream"}}`,
+		want: []vulnFinding{{ID: "GO-2023-1571", Summary: "Denial of service via crafted HTTP/2 stream", Module: "golang.org/x/net", Version: "v0.5.0", FixedVersion: "v0.7.0", Called: true}},
+	}, {
+		name: "sorted by ID",
+		stream: `{"finding": {"osv": "GO-2023-2000", "trace": [{"module": "golang.org/x/crypto", "version": "v0.1.0"}]}}
+{"finding": {"osv": "GO-2022-1000", "trace": [{"module": "golang.org/x/text", "version": "v0.3.7"}]}}
+{"finding": {"osv": "GO-2021-0001", "trace": []}}`,
+		want: []vulnFinding{
+			{ID: "GO-2022-1000", Module: "golang.org/x/text", Version: "v0.3.7"},
+			{ID: "GO-2023-2000", Module: "golang.org/x/crypto", Version: "v0.1.0"},
+		},
+	}, {
+		name:    "malformed",
+		stream:  `{"finding": `,
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseGovulncheck(strings.NewReader(tc.stream))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseGovulncheck() error = %v, want error %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseGovulncheck() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// oldestUntriagedCount is the number of oldest untriaged items listed in the
+// weekly report.
+const oldestUntriagedCount = 10
+
+const (
+	// forkPollInterval is how often openFilePR checks whether the fork it
+	// requested exists, and forkTimeout how long it waits for it at most. The
+	// wait is shortened to half of the time left before the command deadline,
+	// so that the commit and PR can still be made.
+	forkPollInterval = 5 * time.Second
+	forkTimeout      = 5 * time.Minute
+)
+
+// runWeeklyReport renders the weekly triage statistics and optionally opens a
+// PR adding them to the profile's weeklyReport repository.
+func runWeeklyReport(ctx context.Context, tokens, args []string) error {
+	var common commonFlags
+	fs := flag.NewFlagSet("weekly-report", flag.ExitOnError)
+	common.register(fs)
+	snapshotDir := fs.String("snapshot-dir", "", "directory holding the board snapshots written by sync, used to report the changes of the past week")
+	openPR := fs.Bool("open-pr", false, "open a PR adding the report to the profile's weeklyReport repository")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, prof, err := common.load()
+	if err != nil {
+		return err
+	}
+	if *openPR && prof.WeeklyReport == nil {
+		return fmt.Errorf("profile %q has no weeklyReport configured", common.profileName)
+	}
+
+	client := newClient(ctx, tokens)
+	project, err := client.getProject(ctx, orgName, prof.Project)
+	if err != nil {
+		return err
+	}
+	items, err := client.listProjectItems(ctx, project)
+	if err != nil {
+		return err
+	}
+	current := newSnapshot(project, items)
+
+	var week *boardDiff
+	if *snapshotDir != "" {
+		old, err := loadSnapshot(*snapshotDir, time.Now().AddDate(0, 0, -7))
+		if err != nil {
+			return err
+		}
+		d := diffSnapshots(old, current)
+		week = &d
+	}
+
+	report := renderWeeklyReport(prof, current, week)
+	if !*openPR {
+		fmt.Print(report)
+		return nil
+	}
+
+	date := current.Time.Format(dateFormat)
+	file := *prof.WeeklyReport
+	file.Path = strings.ReplaceAll(file.Path, "{date}", date)
+	title := fmt.Sprintf("SIG Auth triage report for %s", date)
+	return client.openFilePR(ctx, file, "sig-auth-triage-report-"+date, title, []byte(report))
+}
+
+func renderWeeklyReport(prof profile, snap *boardSnapshot, week *boardDiff) string {
+	counts := make(map[string]int)
+	var untriaged []snapshotItem
+	for _, item := range snap.Items {
+		if item.State != "OPEN" {
+			continue
+		}
+		counts[item.Status]++
+		// Snoozed items were deliberately deferred, they are not waiting.
+		if _, active := snoozed(item.Fields, snap.Time); active {
+			continue
+		}
+		if prof.isUntriaged(item.Status) {
+			untriaged = append(untriaged, item)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# SIG Auth triage report for %s\n\n", snap.Time.Format(dateFormat))
+	fmt.Fprintf(&b, "Open items on the %q project board by status. %d items are waiting for triage.\n\n", snap.Project, len(untriaged))
+	b.WriteString("| Status | Items |\n| --- | --- |\n")
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		name := status
+		if name == "" {
+			name = "No status"
+		}
+		fmt.Fprintf(&b, "| %s | %d |\n", name, counts[status])
+	}
+
+	if week != nil {
+		b.WriteString("\n## Past week\n\n")
+		fmt.Fprintf(&b, "- %d items added\n- %d items removed\n- %d items moved between statuses\n", len(week.Added), len(week.Removed), len(week.Moved))
+	}
+
+	sort.Slice(untriaged, func(i, j int) bool {
+		return createdBefore(untriaged[i], untriaged[j])
+	})
+	if len(untriaged) > oldestUntriagedCount {
+		untriaged = untriaged[:oldestUntriagedCount]
+	}
+	if len(untriaged) > 0 {
+		b.WriteString("\n## Oldest untriaged items\n\n")
+		for _, item := range untriaged {
+			fmt.Fprintf(&b, "- %s#%d %s\n", item.Repository, item.Number, item.Title)
+		}
+	}
+	return b.String()
+}
+
+// createdBefore reports whether a was created before b. Items without a
+// creation time sort last.
+func createdBefore(a, b snapshotItem) bool {
+	switch {
+	case a.CreatedAt == nil:
+		return false
+	case b.CreatedAt == nil:
+		return true
+	}
+	return a.CreatedAt.Before(*b.CreatedAt)
+}
+
+// openFilePR opens a PR against the file's repository that adds or updates the
+// file with content. The change is pushed to a fork owned by the token user,
+// since the token is not expected to have write access to the repository.
+func (c *ghClient) openFilePR(ctx context.Context, file repoFile, branch, title string, content []byte) error {
+	owner, repo, err := splitRepo(file.Repo)
+	if err != nil {
+		return err
+	}
+
+	user, _, err := c.Users.Get(ctx, "")
+	if err != nil {
+		return err
+	}
+	upstream, _, err := c.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+	base := upstream.GetDefaultBranch()
+	if file.Branch != "" {
+		base = file.Branch
+	}
+	baseRef, _, err := c.Git.GetRef(ctx, owner, repo, "refs/heads/"+base)
+	if err != nil {
+		return err
+	}
+
+	// Forking is asynchronous and returns an AcceptedError while in progress; it
+	// is a no-op if the fork already exists.
+	forked, _, err := c.Repositories.CreateFork(ctx, owner, repo, &github.RepositoryCreateForkOptions{})
+	var accepted *github.AcceptedError
+	if err != nil && !errors.As(err, &accepted) {
+		return err
+	}
+	forkOwner, forkName := forkRepo(forked, user.GetLogin(), repo)
+
+	fork := forkOwner + "/" + forkName
+	if err := c.waitForRepo(ctx, forkOwner, forkName); err != nil {
+		return fmt.Errorf("waiting for fork %s: %w", fork, err)
+	}
+
+	// The branch is left over by an earlier run of the day: it is reset to the
+	// base branch, and its PR, if still open, updated rather than opened again.
+	ref := &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: baseRef.Object.SHA},
+	}
+	_, resp, err := c.Git.GetRef(ctx, forkOwner, forkName, ref.GetRef())
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		_, _, err = c.Git.CreateRef(ctx, forkOwner, forkName, ref)
+	case err == nil:
+		_, _, err = c.Git.UpdateRef(ctx, forkOwner, forkName, ref, true)
+	}
+	if err != nil {
+		return fmt.Errorf("creating branch %s in %s: %w", branch, fork, err)
+	}
+
+	if err := c.commitFile(ctx, repoFile{Repo: fork, Path: file.Path, Branch: branch}, content, title); err != nil {
+		return err
+	}
+
+	open, _, err := c.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  forkOwner + ":" + branch,
+		Base:  base,
+	})
+	if err != nil {
+		return err
+	}
+	if len(open) > 0 {
+		fmt.Printf("updated %s\n", open[0].GetHTMLURL())
+		return nil
+	}
+
+	pr, _, err := c.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.String(title),
+		Head:  github.String(forkOwner + ":" + branch),
+		Base:  github.String(base),
+		Body:  github.String("This PR was opened by [sig-auth-tools](https://github.com/kubernetes-sigs/sig-auth-tools).\n\n/sig auth"),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("opened %s\n", pr.GetHTMLURL())
+	return nil
+}
+
+// waitForRepo waits until the repository exists, as forks are created
+// asynchronously.
+func (c *ghClient) waitForRepo(ctx context.Context, owner, repo string) error {
+	ctx, cancel := context.WithTimeout(ctx, forkWait(ctx, time.Now()))
+	defer cancel()
+	for {
+		_, resp, err := c.Repositories.Get(ctx, owner, repo)
+		if err == nil {
+			return nil
+		}
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(forkPollInterval):
+		}
+	}
+}
+
+// forkRepo returns the owner and name of the fork returned by CreateFork, which
+// may have been renamed or predate the token user's current login. It falls
+// back to login/repo if GitHub did not describe the fork.
+func forkRepo(forked *github.Repository, login, repo string) (string, string) {
+	if forked.GetOwner().GetLogin() == "" || forked.GetName() == "" {
+		return login, repo
+	}
+	return forked.GetOwner().GetLogin(), forked.GetName()
+}
+
+// forkWait returns how long waitForRepo waits for a fork at now: forkTimeout,
+// or half of the time left before the deadline of ctx if that is shorter.
+func forkWait(ctx context.Context, now time.Time) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return forkTimeout
+	}
+	if left := deadline.Sub(now) / 2; left < forkTimeout {
+		return left
+	}
+	return forkTimeout
+}
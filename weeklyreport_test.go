@@ -0,0 +1,81 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+func TestForkRepo(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		forked      *github.Repository
+		owner, repo string
+	}{
+		{name: "no fork", owner: "bot", repo: "community"},
+		{name: "empty fork", forked: &github.Repository{}, owner: "bot", repo: "community"},
+		{
+			name:   "renamed fork",
+			forked: &github.Repository{Owner: &github.User{Login: github.String("bot")}, Name: github.String("k8s-community")},
+			owner:  "bot",
+			repo:   "k8s-community",
+		},
+		{
+			name:   "organization fork",
+			forked: &github.Repository{Owner: &github.User{Login: github.String("sig-auth")}, Name: github.String("community")},
+			owner:  "sig-auth",
+			repo:   "community",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			owner, repo := forkRepo(tc.forked, "bot", "community")
+			if owner != tc.owner || repo != tc.repo {
+				t.Errorf("forkRepo() = %s/%s, want %s/%s", owner, repo, tc.owner, tc.repo)
+			}
+		})
+	}
+}
+
+func TestForkWait(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name string
+		left time.Duration
+		want time.Duration
+	}{
+		{name: "no deadline", want: forkTimeout},
+		{name: "distant deadline", left: time.Hour, want: forkTimeout},
+		{name: "near deadline", left: 3 * time.Minute, want: 90 * time.Second},
+		{name: "passed deadline", left: -time.Minute, want: -30 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.left != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, now.Add(tc.left))
+				defer cancel()
+			}
+			if got := forkWait(ctx, now); got != tc.want {
+				t.Errorf("forkWait() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2023 The Kubernetes Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// workflowConflicts are the built-in project workflows that overlap with the
+// sync, by name, with how.
+var workflowConflicts = map[string]string{
+	"Item added to project":  "sets the status of new items, which the sync sets to their initial status",
+	"Item reopened":          "sets the status of reopened items, which the sync moves back to their initial status",
+	"Item closed":            "sets the status of closed items, which the sync moves to " + statusRecentlyClosed + " unless the workflow already moved them out of the reconciled statuses",
+	"Pull request merged":    "sets the status of merged PRs, which the sync moves to " + statusRecentlyClosed + " unless the workflow already moved them out of the reconciled statuses",
+	"Code changes requested": "sets the status of PRs with changes requested, which the sync moves to " + statusWaitingOnAuthor,
+	"Code review approved":   "sets the status of approved PRs, which the sync moves back to their initial status",
+	"Auto-add to project":    "adds items without the source, bot and exemptLabels filters of the sync",
+}
+
+// listEnabledWorkflows returns the names of the enabled built-in workflows of
+// the project.
+func (c *ghClient) listEnabledWorkflows(ctx context.Context, p *project) ([]string, error) {
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Workflows struct {
+					Nodes []struct {
+						Name    githubql.String  `graphql:"name"`
+						Enabled githubql.Boolean `graphql:"enabled"`
+					} `graphql:"nodes"`
+				} `graphql:"workflows(first: 50)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": p.ID,
+	}
+	if err := c.v4Client.Query(ctx, &query, variables); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, workflow := range query.Node.ProjectV2.Workflows.Nodes {
+		if workflow.Enabled {
+			names = append(names, string(workflow.Name))
+		}
+	}
+	return names, nil
+}
+
+// checkWorkflows warns about the enabled built-in workflows of the project
+// that overlap with the sync, which would otherwise silently ping-pong item
+// statuses.
+func (s *syncer) checkWorkflows(ctx context.Context) error {
+	names, err := s.client.listEnabledWorkflows(ctx, s.project)
+	if err != nil {
+		return err
+	}
+	archives := false
+	for _, policy := range s.profile.Policies {
+		archives = archives || policy.Action == policyArchive
+	}
+	for _, name := range names {
+		conflict, ok := workflowConflicts[name]
+		if name == "Auto-archive items" && archives {
+			conflict, ok = "archives items independently of the archive policies", true
+		}
+		if !ok {
+			continue
+		}
+		fmt.Printf("WARNING: the built-in %q workflow of project %q %s\n", name, s.project.Title, conflict)
+	}
+	return nil
+}